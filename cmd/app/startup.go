@@ -2,81 +2,39 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
-	"api-ptf-core-business-orchestrator-go-ms/internal/models"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
+	"api-ptf-core-business-orchestrator-go-ms/internal/application"
 	"api-ptf-core-business-orchestrator-go-ms/internal/config"
 	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/repository"
 	httpServer "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/modules"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/health"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/i18n"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/lifecycle"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/tlsconfig"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/tracing"
 
 	"go.uber.org/zap"
 )
 
 type applicationWrapper struct {
 	*models.Application
-}
-
-// run starts the HTTP server and keeps it running until a shutdown signal is received
-func (aw *applicationWrapper) run(ctx context.Context) error {
-	// Create HTTP server with our router
-	router := httpServer.NewRouter(aw.Application)
-	srv := &http.Server{
-		Addr:    ":" + aw.Configs().HTTP.Port,
-		Handler: router,
-	}
-
-	// Server run context
-	serverCtx, serverStopCtx := context.WithCancel(context.Background())
-
-	// Listen for syscall signals for process shutdown
-	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
-		<-sigint
-
-		// Shutdown signal with grace period of 30 seconds
-		shutdownCtx, cancel := context.WithTimeout(serverCtx, 30*time.Second)
-		defer cancel()
-
-		go func() {
-			<-shutdownCtx.Done()
-			if shutdownCtx.Err() == context.DeadlineExceeded {
-				logger.Log.Fatal("Graceful shutdown timed out. Forcing exit.")
-			}
-		}()
-
-		// Trigger graceful shutdown
-		err := srv.Shutdown(shutdownCtx)
-		if err != nil {
-			logger.Log.Error("HTTP server shutdown error", zap.Error(err))
-		}
-		serverStopCtx()
-	}()
-
-	// Start the server
-	logger.Log.Info("Starting HTTP server",
-		zap.String("context_path", aw.Configs().HTTP.BasePath),
-		zap.String("port", aw.Configs().HTTP.Port),
-	)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to start server: %w", err)
-	}
-
-	// Wait for server context to be stopped
-	<-serverCtx.Done()
-	logger.Log.Info("Server stopped")
-	return nil
+	shutdownTracing func(context.Context) error
 }
 
 func StartUp() {
@@ -86,86 +44,223 @@ func StartUp() {
 	}
 	defer logger.Sync()
 
-	// Configuración del contexto
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Configuración de señales
-	setupGracefulShutdown(cancel)
+	// Único manejador de señales: el contexto se cancela con SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Inicialización de la aplicación
 	app, err := initializeApp(ctx)
 	if err != nil {
 		logger.Log.Fatal("Failed to initialize application services", zap.Error(err))
 	}
-	defer app.cleanup(ctx)
 
-	// Iniciar la aplicación
-	if err := app.run(ctx); err != nil {
+	// Registrar y validar las dependencias antes de inicializar los módulos
+	if err := app.registerProviders(); err != nil {
+		logger.Log.Fatal("Failed to resolve dependencies", zap.Error(err))
+	}
+
+	// Inicializar los módulos de negocio habilitados
+	if err := modules.Init(app.Application); err != nil {
+		logger.Log.Fatal("Failed to initialize modules", zap.Error(err))
+	}
+
+	// Registrar los componentes en el ciclo de vida
+	if err := app.registerLifecycleHooks(); err != nil {
+		logger.Log.Fatal("Failed to configure application components", zap.Error(err))
+	}
+
+	// Iniciar la aplicación y esperar la señal de apagado
+	if err := app.Lifecycle().Run(ctx, app.ShutdownTimeout()); err != nil {
 		logger.Log.Fatal("Application error", zap.Error(err))
 	}
 }
 
-// run starts the HTTP server and keeps it running until a shutdown signal is received
-func run(ctx context.Context) error {
-	// Create HTTP server with our router
-	app := models.NewEmptyApplication()
-	router := httpServer.NewRouter(app)
-	srv := &http.Server{
-		Addr:    ":" + app.Configs().HTTP.Port,
-		Handler: router,
+// registerProviders registers the repositories and services in the
+// dependency container, builds them once to fail fast on missing
+// dependencies or cycles, and logs the resulting dependency graph
+func (aw *applicationWrapper) registerProviders() error {
+	c := aw.Container()
+	repository.RegisterProviders(c)
+	application.RegisterProviders(c)
+
+	if aw.MongoDB() == nil {
+		logger.Log.Warn("Dependency validation deferred until MongoDB is available")
+		return nil
 	}
+	return aw.validateProviders()
+}
 
-	// Server run context
-	serverCtx, serverStopCtx := context.WithCancel(context.Background())
-
-	// Listen for syscall signals for process shutdown
-	go func() {
-		sigint := make(chan os.Signal, 1)
-		signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
-		<-sigint
+// validateProviders builds every provider and logs the dependency graph
+func (aw *applicationWrapper) validateProviders() error {
+	c := aw.Container()
+	if err := c.Validate(); err != nil {
+		return err
+	}
 
-		// Shutdown signal with grace period of 30 seconds
-		shutdownCtx, cancel := context.WithTimeout(serverCtx, 30*time.Second)
-		defer cancel()
+	graph := c.Graph()
+	names := make([]string, 0, len(graph))
+	for name := range graph {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		logger.Log.Info("Dependency registered", zap.String("provider", name), zap.Strings("depends_on", graph[name]))
+	}
+	return nil
+}
 
-		go func() {
-			<-shutdownCtx.Done()
-			if shutdownCtx.Err() == context.DeadlineExceeded {
-				logger.Log.Fatal("Graceful shutdown timed out. Forcing exit.")
+// registerLifecycleHooks registers every component with the lifecycle
+// manager. On shutdown readiness is withdrawn first, in-flight requests are
+// drained, background workers stop and the database closes last.
+func (aw *applicationWrapper) registerLifecycleHooks() error {
+	lm := aw.Lifecycle()
+	cfg := aw.Configs()
+
+	// Infraestructura: se detiene al final
+	lm.Register(lifecycle.Hook{
+		Name:  "mongodb",
+		Order: lifecycle.OrderInfrastructure,
+		Stop: func(ctx context.Context) error {
+			if aw.MongoDB() == nil {
+				return nil
 			}
-		}()
+			logger.Log.Info("Disconnecting from database...")
+			return aw.MongoDB().Disconnect(ctx)
+		},
+	})
+	if aw.shutdownTracing != nil {
+		lm.Register(lifecycle.Hook{
+			Name:    "tracing",
+			Order:   lifecycle.OrderInfrastructure - 1,
+			Timeout: 5 * time.Second,
+			Stop:    aw.shutdownTracing,
+		})
+	}
+
+	// Modo degradado: reconectar MongoDB en segundo plano
+	if aw.MongoDB() == nil {
+		lm.Register(workerHook("mongodb-reconnect", aw.reconnectDatabase))
+	}
 
-		// Trigger graceful shutdown
-		err := srv.Shutdown(shutdownCtx)
+	// Listas de acceso: recarga de los params del JSON config
+	lm.Register(workerHook("access-lists", aw.AccessLists().Start))
+
+	// Servidor HTTP público, con TLS opcional
+	router, err := httpServer.NewRouter(aw.Application)
+	if err != nil {
+		return fmt.Errorf("failed to build HTTP routes: %w", err)
+	}
+	srv := &http.Server{
+		Addr:         ":" + cfg.HTTP.Port,
+		Handler:      router,
+		ReadTimeout:  config.ParseDuration(cfg.HTTP.ReadTimeout, 30*time.Second),
+		WriteTimeout: config.ParseDuration(cfg.HTTP.WriteTimeout, 30*time.Second),
+		IdleTimeout:  config.ParseDuration(cfg.HTTP.IdleTimeout, 120*time.Second),
+	}
+	if cfg.HTTP.TLS.Enabled {
+		reloader, err := tlsconfig.New(cfg.HTTP.TLS)
 		if err != nil {
-			logger.Log.Error("HTTP server shutdown error", zap.Error(err))
+			return fmt.Errorf("failed to load TLS configuration: %w", err)
 		}
-		serverStopCtx()
-	}()
+		if srv.TLSConfig, err = reloader.Config(); err != nil {
+			return fmt.Errorf("failed to build TLS configuration: %w", err)
+		}
+		lm.Register(workerHook("tls-reloader", reloader.Start))
+	}
 
-	// Start the server
-	logger.Log.Info("Starting HTTP server",
-		zap.String("context_path", app.Configs().HTTP.BasePath),
-		zap.String("port", app.Configs().HTTP.Port),
+	logger.Log.Info("Configuring HTTP server",
+		zap.String("context_path", cfg.HTTP.BasePath),
+		zap.String("api_root", cfg.HTTP.APIRoot()),
+		zap.String("default_api_version", cfg.HTTP.Versioning.Default),
+		zap.String("port", cfg.HTTP.Port),
+		zap.Bool("tls", srv.TLSConfig != nil),
+		zap.Duration("read_timeout", srv.ReadTimeout),
+		zap.Duration("write_timeout", srv.WriteTimeout),
+		zap.Duration("idle_timeout", srv.IdleTimeout),
 	)
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("failed to start server: %w", err)
+	lm.Register(lm.ServerHook("http", lifecycle.OrderServers, srv))
+
+	// Métricas y administración se sirven en sus propios listeners
+	if metricsSrv := httpServer.NewMetricsServer(aw.Application); metricsSrv != nil {
+		lm.Register(lm.ServerHook("metrics", lifecycle.OrderServers, metricsSrv))
 	}
+	adminSrv, err := httpServer.NewAdminServer(aw.Application)
+	if err != nil {
+		return fmt.Errorf("failed to build admin routes: %w", err)
+	}
+	if adminSrv != nil {
+		lm.Register(lm.ServerHook("admin", lifecycle.OrderServers, adminSrv))
+	}
+
+	// Readiness: se marca como no listo antes de drenar las peticiones
+	drainDelay, _ := time.ParseDuration(cfg.Timeouts.DrainDelay)
+	lm.Register(lifecycle.Hook{
+		Name:    "readiness",
+		Order:   lifecycle.OrderReadiness,
+		Timeout: drainDelay + time.Second,
+		Start: func(ctx context.Context) error {
+			aw.Health().SetReady(true)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			aw.Health().SetReady(false)
+			if drainDelay <= 0 {
+				return nil
+			}
+			select {
+			case <-time.After(drainDelay):
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
 
-	// Wait for server context to be stopped
-	<-serverCtx.Done()
-	logger.Log.Info("Server stopped")
 	return nil
 }
 
-// cleanup realiza la limpieza de recursos de la aplicación
-func (a *applicationWrapper) cleanup(ctx context.Context) {
-	if a.MongoDB() != nil {
-		logger.Log.Info("Disconnecting from database...")
-		if err := a.MongoDB().Disconnect(ctx); err != nil {
-			logger.Log.Error("Error disconnecting from database", zap.Error(err))
-		}
+// reconnectDatabase retries the MongoDB connection with backoff and, once
+// connected, publishes the database to the application and validates the
+// providers that depend on it
+func (aw *applicationWrapper) reconnectDatabase(ctx context.Context) {
+	mongoCfg := aw.Configs().App.MongoDB
+	db, err := database.Reconnect(ctx, aw.Configs(),
+		config.ParseDuration(mongoCfg.ReconnectInitialBackoff, time.Second),
+		config.ParseDuration(mongoCfg.ReconnectMaxBackoff, 30*time.Second),
+	)
+	if err != nil {
+		return
+	}
+	aw.SetDB(db)
+	if err := aw.validateProviders(); err != nil {
+		aw.Lifecycle().Fail(fmt.Errorf("failed to resolve dependencies after reconnecting: %w", err))
+		return
+	}
+	logger.Log.Info("Leaving degraded mode, MongoDB is available")
+}
+
+// workerHook runs fn in the background until the application stops
+func workerHook(name string, fn func(ctx context.Context)) lifecycle.Hook {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	return lifecycle.Hook{
+		Name:  name,
+		Order: lifecycle.OrderWorkers,
+		Start: func(context.Context) error {
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		Stop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
 	}
 }
 
@@ -192,14 +287,63 @@ func initializeApp(ctx context.Context) (*applicationWrapper, error) {
 		return nil, fmt.Errorf("failed to load config from %s: %w", configPath, err)
 	}
 
+	// Idioma de los mensajes cuando Accept-Language no coincide con ningún catálogo
+	if err := i18n.SetDefault(config.App.DefaultLanguage); err != nil {
+		return nil, fmt.Errorf("invalid app.default_language: %w", err)
+	}
+
 	// Ensure MongoDB URI is properly formatted
 	if !strings.HasPrefix(config.MongoURI, "mongodb://") && !strings.HasPrefix(config.MongoURI, "mongodb+srv://") {
 		config.MongoURI = "mongodb://" + config.MongoURI
 	}
 
-	// Inicializar conexión a MongoDB
-	db, err := database.NewDatabase(config)
+	// Inicializar conexión a MongoDB según la política de arranque
+	db, err := connectDatabase(ctx, config)
 	if err != nil {
+		return nil, err
+	}
+
+	// Crear la aplicación usando el constructor
+	app := &applicationWrapper{Application: models.NewApplication(config, db)}
+
+	// No se reciben peticiones hasta que todos los componentes hayan iniciado
+	app.Health().SetReady(false)
+
+	// Registrar los chequeos de salud de los componentes
+	app.Health().Register("mongodb", true, health.CheckerFunc(func(ctx context.Context) error {
+		return app.MongoDB().Ping(ctx)
+	}))
+	if config.App.JSONConfigPath != "" {
+		app.Health().Register("json_config", true, health.JSONConfigChecker())
+	}
+	for _, integration := range config.Health.Integrations {
+		app.Health().Register("integration:"+integration, true, health.IntegrationChecker(integration))
+	}
+
+	// Inicializar el exportador de trazas
+	if httpServer.TracingEnabled(app.Application) {
+		shutdown, err := tracing.Init(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+		}
+		app.shutdownTracing = shutdown
+		logger.Log.Info("Tracing enabled",
+			zap.String("collector_endpoint", config.Observability.Tracing.CollectorEndpoint))
+	}
+
+	return app, nil
+}
+
+// connectDatabase connects to MongoDB. With the fail_fast policy any failure
+// aborts startup; with the degraded policy an unreachable database yields a
+// nil *Database and the reconnect worker brings it online later.
+func connectDatabase(ctx context.Context, cfg *config.Config) (*database.Database, error) {
+	db, err := database.NewDatabase(cfg)
+	if err != nil {
+		if cfg.App.MongoDB.StartupPolicy == config.StartupPolicyDegraded && errors.Is(err, database.ErrUnavailable) {
+			logger.Log.Warn("MongoDB is unavailable, starting in degraded mode", zap.Error(err))
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to create database client: %w", err)
 	}
 
@@ -224,20 +368,5 @@ func initializeApp(ctx context.Context) (*applicationWrapper, error) {
 	}
 
 	logger.Log.Info("Successfully connected to MongoDB")
-
-	// Crear la aplicación usando el constructor
-	return &applicationWrapper{Application: models.NewApplication(config, db)}, nil
-}
-
-// setupGracefulShutdown configura el manejo de señales para un apagado controlado
-func setupGracefulShutdown(cancelFunc context.CancelFunc) {
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-	go func() {
-		sig := <-sigChan
-		logger.Log.Info("Received signal, shutting down...",
-			zap.String("signal", sig.String()))
-		cancelFunc()
-	}()
+	return db, nil
 }
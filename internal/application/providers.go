@@ -0,0 +1,27 @@
+package application
+
+import (
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/repository"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/container"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+)
+
+// RegisterProviders registra los servicios de aplicación en el contenedor
+func RegisterProviders(c *container.Container) {
+	container.Provide(c, func(c *container.Container) (*UserService, error) {
+		genericRepo, err := container.Resolve[*repository.GenericRepository[domain.User]](c)
+		if err != nil {
+			return nil, err
+		}
+		userRepo, err := container.Resolve[repository.UserRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := container.Resolve[*cursor.Signer](c)
+		if err != nil {
+			return nil, err
+		}
+		return NewUserService(genericRepo, userRepo, signer), nil
+	})
+}
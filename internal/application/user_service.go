@@ -3,14 +3,17 @@ package application
 import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
 	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/repository"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
 	"context"
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/mail"
 	"time"
 
-	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
@@ -20,19 +23,135 @@ import (
 type UserService struct {
 	genericRepo *repository.GenericRepository[domain.User]
 	userRepo    repository.UserRepository
+	cursors     *cursor.Signer
 }
 
 // NewUserService crea una nueva instancia de UserService
-func NewUserService(genericRepo *repository.GenericRepository[domain.User], userRepo repository.UserRepository) *UserService {
+func NewUserService(genericRepo *repository.GenericRepository[domain.User], userRepo repository.UserRepository, cursors *cursor.Signer) *UserService {
 	return &UserService{
 		genericRepo: genericRepo,
 		userRepo:    userRepo,
+		cursors:     cursors,
 	}
 }
 
 // GetUserByID obtiene un usuario por su ID
 func (s *UserService) GetUserByID(ctx context.Context, id string) (*domain.User, error) {
-	return s.genericRepo.FindByID(ctx, id)
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidUserID, id)
+	}
+	user, err := s.genericRepo.FindByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+	}
+	return user, err
+}
+
+// minPasswordLength es la longitud mínima de una contraseña nueva
+const minPasswordLength = 6
+
+// UserChanges son los campos editables de un usuario; los nil no se modifican
+type UserChanges struct {
+	Email    *string
+	Password *string
+	// Version, si no está vacía, es la versión del usuario (domain.User.Version)
+	// sobre la que se calcularon los cambios, normalmente la del If-Match
+	Version string
+}
+
+// UpdateUser aplica changes al usuario id. El email se vuelve a comprobar
+// contra los demás usuarios cuando cambia y la contraseña se guarda hasheada.
+// La escritura solo se aplica si el usuario no cambió desde que se leyó; si
+// cambió devuelve domain.ErrUserModified.
+func (s *UserService) UpdateUser(ctx context.Context, id string, changes UserChanges) (*domain.User, error) {
+	user, err := s.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if changes.Version != "" && changes.Version != user.Version() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserModified, id)
+	}
+	if changes.Email == nil && changes.Password == nil {
+		return user, nil
+	}
+
+	if changes.Email != nil && *changes.Email != user.Email {
+		email := *changes.Email
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			return nil, fmt.Errorf("%w: invalid email %q", domain.ErrInvalidUser, email)
+		}
+		existing, err := s.userRepo.FindByEmail(ctx, email)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("error checking for existing user: %w", err)
+		}
+		if existing != nil && existing.ID != user.ID {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUserEmailTaken, email)
+		}
+		user.Email = email
+	}
+
+	if changes.Password != nil {
+		if len(*changes.Password) < minPasswordLength {
+			return nil, fmt.Errorf("%w: password shorter than %d characters", domain.ErrInvalidUser, minPasswordLength)
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(*changes.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing password: %w", err)
+		}
+		user.Password = string(hashedPassword)
+	}
+
+	logger.Logger().Info("Updating user", zap.String("user_id", id), zap.String("email", user.Email))
+
+	// Update fija DateUpdated y solo escribe si sigue siendo la leída
+	err = s.userRepo.Update(ctx, id, user, user.DateUpdated)
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// Otra escritura modificó o eliminó el usuario desde la lectura
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserModified, id)
+	case mongo.IsDuplicateKeyError(err):
+		// Otro usuario tomó el email entre la comprobación y la escritura
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserEmailTaken, user.Email)
+	case err != nil:
+		return nil, fmt.Errorf("error updating user: %w", err)
+	}
+	return user, nil
+}
+
+// DeleteUser elimina el usuario id. Con version (domain.User.Version) solo
+// lo elimina si no cambió desde esa versión; si cambió devuelve
+// domain.ErrUserModified.
+func (s *UserService) DeleteUser(ctx context.Context, id, version string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidUserID, id)
+	}
+
+	var expected *time.Time
+	if version != "" {
+		updated, ok := domain.ParseVersion(version)
+		if !ok {
+			return fmt.Errorf("%w: %s", domain.ErrUserModified, id)
+		}
+		expected = &updated
+	}
+
+	logger.Logger().Info("Deleting user", zap.String("user_id", id))
+
+	err := s.userRepo.Delete(ctx, id, expected)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if expected == nil {
+			return fmt.Errorf("%w: %s", domain.ErrUserNotFound, id)
+		}
+		// Sin coincidencia: o ya no existe o cambió de versión
+		if _, err := s.GetUserByID(ctx, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s", domain.ErrUserModified, id)
+	}
+	if err != nil {
+		return fmt.Errorf("error deleting user: %w", err)
+	}
+	return nil
 }
 
 // UserRepository define la interfaz para operaciones de datos de usuarios
@@ -52,9 +171,24 @@ func (s *UserService) CountUsers(ctx context.Context) (int64, error) {
 	return s.genericRepo.Count(ctx)
 }
 
-// ListUsers returns a paginated list of users
-func (s *UserService) ListUsers(ctx context.Context, page, limit int64) ([]domain.User, error) {
-	return s.genericRepo.FindAll(ctx, page, limit)
+// ListUsers returns a page of the users matching q along with the total
+// number of matching users
+func (s *UserService) ListUsers(ctx context.Context, q listquery.Query, page, limit int64) ([]domain.User, int64, error) {
+	users, err := s.genericRepo.FindPage(ctx, q, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.genericRepo.CountMatching(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ListUsersByCursor returns the page of the users matching q that follows
+// token, or the first page when token is empty
+func (s *UserService) ListUsersByCursor(ctx context.Context, q listquery.Query, token string, limit int64) (*repository.CursorPage[domain.User], error) {
+	return s.genericRepo.FindByCursor(ctx, s.cursors, q, token, limit)
 }
 
 // generateRandomPassword generates a random 6-character string
@@ -77,13 +211,11 @@ func (s *UserService) Create(ctx context.Context, user *domain.User) (*domain.Us
 	}
 
 	if existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", user.Email)
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserEmailTaken, user.Email)
 	}
 
-	// Set timestamps and ID
-	now := time.Now()
-	user.ID = uuid.New().String()
-	user.DateCreated = now
+	// MongoDB asigna el _id (ObjectID) y el repositorio las fechas
+	user.ID = ""
 
 	// Generate random password if not provided
 	if user.Password == "" {
@@ -97,10 +229,13 @@ func (s *UserService) Create(ctx context.Context, user *domain.User) (*domain.Us
 	}
 	user.Password = string(hashedPassword)
 
-	logger.Logger().Info("Creating user", zap.String("user_id", user.ID), zap.String("email", user.Email))
+	logger.Logger().Info("Creating user", zap.String("email", user.Email))
 
 	// Create the user
-	id, err := s.genericRepo.Create(ctx, user)
+	id, err := s.userRepo.Create(ctx, user)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUserEmailTaken, user.Email)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error creating user: %w", err)
 	}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/repository"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+const usersNamespace = "db.onb-ptf-users"
+
+func newMockUserService(mt *mtest.T) *UserService {
+	db := database.Wrap(mt.DB)
+	return NewUserService(
+		repository.NewGenericRepository[domain.User](db, "onb-ptf-users"),
+		repository.NewMongoUserRepository(db),
+		cursor.NewSigner(nil),
+	)
+}
+
+// storedUser es el documento que MongoDB devuelve para user
+func storedUser(user *domain.User) bson.D {
+	oid, _ := primitive.ObjectIDFromHex(user.ID)
+	return bson.D{
+		{Key: "_id", Value: oid},
+		{Key: "email", Value: user.Email},
+		{Key: "pass", Value: user.Password},
+		{Key: "date_created", Value: user.DateCreated},
+		{Key: "updated_created", Value: user.DateUpdated},
+	}
+}
+
+// lastCommand devuelve el último comando enviado con ese nombre
+func lastCommand(mt *mtest.T, name string) bson.Raw {
+	var cmd bson.Raw
+	for _, e := range mt.GetAllStartedEvents() {
+		if e.CommandName == name {
+			cmd = e.Command
+		}
+	}
+	require.NotNil(mt, cmd, "no %s command was sent", name)
+	return cmd
+}
+
+func TestUserRoundTrip(t *testing.T) {
+	_ = logger.InitLogger(false)
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	ctx := context.Background()
+
+	mt.Run("CreateUpdateDelete", func(mt *mtest.T) {
+		svc := newMockUserService(mt)
+
+		// Create: el email está libre y MongoDB asigna el _id
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+		)
+		created, err := svc.Create(ctx, &domain.User{Email: "a@b.co", Password: "secret1"})
+		require.NoError(mt, err)
+		oid, err := primitive.ObjectIDFromHex(created.ID)
+		require.NoError(mt, err, "the created id must be an ObjectID")
+		inserted := lastCommand(mt, "insert").Lookup("documents").Array().Index(0).Value().Document()
+		assert.Equal(mt, oid, inserted.Lookup("_id").ObjectID())
+		assert.False(mt, created.DateUpdated.IsZero())
+
+		// Update con la versión del create
+		email := "c@d.co"
+		mt.ClearEvents()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch, storedUser(created)),
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+		updated, err := svc.UpdateUser(ctx, created.ID, UserChanges{Email: &email, Version: created.Version()})
+		require.NoError(mt, err)
+		assert.Equal(mt, email, updated.Email)
+		filter := lastCommand(mt, "update").Lookup("updates").Array().Index(0).Value().Document().Lookup("q").Document()
+		assert.Equal(mt, oid, filter.Lookup("_id").ObjectID())
+		assert.Equal(mt, created.DateUpdated.UnixMilli(), filter.Lookup("updated_created").Time().UnixMilli())
+
+		// Delete con la versión del update
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		require.NoError(mt, svc.DeleteUser(ctx, created.ID, updated.Version()))
+		filter = lastCommand(mt, "delete").Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+		assert.Equal(mt, oid, filter.Lookup("_id").ObjectID())
+		assert.Equal(mt, updated.DateUpdated.UnixMilli(), filter.Lookup("updated_created").Time().UnixMilli())
+	})
+
+	mt.Run("ConcurrentUpdateIsRejected", func(mt *mtest.T) {
+		svc := newMockUserService(mt)
+		user := &domain.User{ID: primitive.NewObjectID().Hex(), Email: "a@b.co"}
+		pass := "secret2"
+
+		// Otra escritura cambió updated_created entre la lectura y el update
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch, storedUser(user)),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+		)
+		_, err := svc.UpdateUser(ctx, user.ID, UserChanges{Password: &pass})
+		assert.ErrorIs(mt, err, domain.ErrUserModified)
+
+		// La versión del If-Match ya no es la actual
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch, storedUser(user)))
+		_, err = svc.UpdateUser(ctx, user.ID, UserChanges{Password: &pass, Version: "1"})
+		assert.ErrorIs(mt, err, domain.ErrUserModified)
+	})
+
+	mt.Run("ConditionalDelete", func(mt *mtest.T) {
+		svc := newMockUserService(mt)
+		user := &domain.User{ID: primitive.NewObjectID().Hex(), Email: "a@b.co"}
+
+		// No coincide la versión pero el usuario existe: 412
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}),
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch, storedUser(user)),
+		)
+		assert.ErrorIs(mt, svc.DeleteUser(ctx, user.ID, "1"), domain.ErrUserModified)
+
+		// No coincide y el usuario ya no existe: 404
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}),
+			mtest.CreateCursorResponse(0, usersNamespace, mtest.FirstBatch),
+		)
+		assert.ErrorIs(mt, svc.DeleteUser(ctx, user.ID, "1"), domain.ErrUserNotFound)
+
+		// Sin versión la eliminación no se condiciona
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}))
+		require.NoError(mt, svc.DeleteUser(ctx, user.ID, ""))
+		filter := lastCommand(mt, "delete").Lookup("deletes").Array().Index(0).Value().Document().Lookup("q").Document()
+		_, err := filter.LookupErr("updated_created")
+		assert.Error(mt, err)
+	})
+}
@@ -1,12 +1,15 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
 )
 
 type RequestData struct {
+	Context     context.Context // Context of the caller; carries the trace, cancellation and deadline
+	Name        string          // Integration name used to label metrics and spans, e.g. "examples.one"
 	Host        string
 	Port        int
 	ContextPath string
@@ -49,3 +52,19 @@ func (r *RequestData) BuildURL() (string, error) {
 
 	return u.String(), nil
 }
+
+// context returns the request context, defaulting to context.Background
+func (r *RequestData) context() context.Context {
+	if r.Context != nil {
+		return r.Context
+	}
+	return context.Background()
+}
+
+// integrationName returns the name used to label metrics, falling back to the host
+func (r *RequestData) integrationName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Host
+}
@@ -2,11 +2,18 @@ package client
 
 import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/tracing"
 	"bytes"
 	"io/ioutil"
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -27,9 +34,21 @@ func (rc *RestClient) doRequest(method string, reqData *RequestData) ([]byte, in
 		return nil, 0, err
 	}
 
+	integration := reqData.integrationName()
+	ctx, span := tracing.Tracer().Start(reqData.context(), "HTTP "+method+" "+integration,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("server.address", reqData.Host),
+			attribute.String("integration.name", integration),
+		),
+	)
+	defer span.End()
+
 	// Crear request
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(reqData.Body))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(reqData.Body))
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		LogError(err)
 		return nil, 0, err
 	}
@@ -38,22 +57,35 @@ func (rc *RestClient) doRequest(method string, reqData *RequestData) ([]byte, in
 	for k, v := range reqData.Headers {
 		req.Header.Set(k, v)
 	}
+	// Propagar traceparent/tracestate al servicio destino
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	// Logging entrada
 	LogRequest(method, url, reqData.Headers, reqData.Body)
 
 	start := time.Now()
+	done := metrics.ClientRequestStarted(integration, method)
 
 	// Hacer la llamada
 	resp, err := rc.HttpClient.Do(req)
 	if err != nil {
+		done(0)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		LogError(err)
 		return nil, 0, err
 	}
 	defer resp.Body.Close()
 
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
 	respBody, err := ioutil.ReadAll(resp.Body)
+	done(resp.StatusCode)
 	if err != nil {
+		span.RecordError(err)
 		LogError(err)
 		return nil, resp.StatusCode, err
 	}
@@ -3,8 +3,11 @@ package config
 import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
 	"fmt"
+	"net/netip"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
@@ -13,38 +16,320 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	AppName         string      `yaml:"application_name"`
-	Description     string      `yaml:"description"`
-	Version         string      `yaml:"application_version"`
-	Uuid            string      `yaml:"entity_uuid"`
-	Environment     string      `yaml:"environment"`
-	MongoURI        string      `yaml:"-"`
-	MongoDB         string      `yaml:"-"`
-	MongoCollection string      `yaml:"-"`
-	HTTP            HTTPConfig  `yaml:"http"`
-	App             AppConfig   `yaml:"app"`
-	JSONConfig      *JSONConfig // Embedded JSON configuration
+	AppName         string                  `yaml:"application_name"`
+	Description     string                  `yaml:"description"`
+	Version         string                  `yaml:"application_version"`
+	Uuid            string                  `yaml:"entity_uuid"`
+	Environment     string                  `yaml:"environment"`
+	MongoURI        string                  `yaml:"-"`
+	MongoDB         string                  `yaml:"-"`
+	MongoCollection string                  `yaml:"-"`
+	HTTP            HTTPConfig              `yaml:"http"`
+	App             AppConfig               `yaml:"app"`
+	Admin           AdminConfig             `yaml:"admin"`
+	Observability   ObservabilityConfig     `yaml:"observability"`
+	Health          HealthConfig            `yaml:"health"`
+	Timeouts        TimeoutsConfig          `yaml:"timeouts"`
+	Modules         map[string]ModuleConfig `yaml:"modules"`
+	Routes          map[string]RoutePolicy  `yaml:"routes"`
+	AccessLists     AccessListsConfig       `yaml:"access_lists"`
+	JSONConfig      *JSONConfig             // Embedded JSON configuration
 }
 
 // HTTPConfig holds HTTP server configuration
 type HTTPConfig struct {
-	Port         string `yaml:"port"`
-	BasePath     string `yaml:"base_path"`
-	ReadTimeout  string `yaml:"read_timeout"`
-	WriteTimeout string `yaml:"write_timeout"`
-	IdleTimeout  string `yaml:"idle_timeout"`
+	Port         string                `yaml:"port"`
+	BasePath     string                `yaml:"base_path"`
+	ReadTimeout  string                `yaml:"read_timeout"`
+	WriteTimeout string                `yaml:"write_timeout"`
+	IdleTimeout  string                `yaml:"idle_timeout"`
+	TLS          TLSConfig             `yaml:"tls"`
+	Versioning   VersioningConfig      `yaml:"versioning"`
+	Security     SecurityHeadersConfig `yaml:"security_headers"`
+	// TrustedProxies are the CIDRs or IPs of the load balancers and proxies
+	// whose forwarding headers are trusted to carry the client address
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
+// TrustedProxyPrefixes returns TrustedProxies as prefixes; single IPs become
+// host prefixes. Entries are validated when the configuration is loaded.
+func (c HTTPConfig) TrustedProxyPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, entry := range c.TrustedProxies {
+		if prefix, err := ParseCIDR(entry); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// ParseCIDR parses a CIDR or a single IP, which becomes a host prefix
+func ParseCIDR(entry string) (netip.Prefix, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		return prefix.Masked(), err
+	}
+	ip, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	ip = ip.Unmap()
+	return netip.PrefixFrom(ip, ip.BitLen()), nil
+}
+
+// ParseDuration parses a config duration, falling back to def when it is
+// empty, invalid or not positive
+func ParseDuration(value string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
+}
+
+// SecurityHeadersConfig holds the hardening headers sent with every response.
+// Routes override them through the headers of their policy.
+type SecurityHeadersConfig struct {
+	Enabled                   *bool    `yaml:"enabled"`                      // Enabled when omitted
+	HSTSMaxAge                int      `yaml:"hsts_max_age"`                 // Seconds; HSTS is only sent when TLS is enabled
+	HSTSIncludeSubdomains     bool     `yaml:"hsts_include_subdomains"`      // Adds includeSubDomains
+	HSTSPreload               bool     `yaml:"hsts_preload"`                 // Adds preload
+	FrameOptions              string   `yaml:"frame_options"`                // X-Frame-Options, DENY by default
+	ReferrerPolicy            string   `yaml:"referrer_policy"`              // Referrer-Policy, no-referrer by default
+	ContentSecurityPolicy     string   `yaml:"content_security_policy"`      // CSP of API responses
+	DocsContentSecurityPolicy string   `yaml:"docs_content_security_policy"` // CSP of the Swagger UI page
+	StripHeaders              []string `yaml:"strip_headers"`                // Server-identifying headers removed from responses
+}
+
+// IsEnabled reports whether the security headers are sent
+func (c SecurityHeadersConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// VersioningConfig declares the API versions mounted side by side. Versions
+// live next to base_path: with base_path /api/v1, v2 is served at /api/v2.
+type VersioningConfig struct {
+	Default  string          `yaml:"default"`  // Version for unversioned paths without Accept-Version
+	Versions []VersionConfig `yaml:"versions"` // Oldest first; routes fall through to the previous version
+}
+
+// VersionConfig holds the lifecycle of an API version
+type VersionConfig struct {
+	Name         string `yaml:"name"`          // Path segment and Accept-Version value, e.g. v2
+	Deprecated   bool   `yaml:"deprecated"`    // Emits the Deprecation header
+	DeprecatedAt string `yaml:"deprecated_at"` // Optional date (2006-01-02) sent in the Deprecation header
+	Sunset       string `yaml:"sunset"`        // Optional date (2006-01-02) sent in the Sunset header
+	Link         string `yaml:"link"`          // Optional migration guide sent as a deprecation Link
+}
+
+// APIRoot returns the path the API versions are mounted under: base_path
+// without its trailing version segment
+func (h HTTPConfig) APIRoot() string {
+	root := h.BasePath
+	for _, v := range h.Versioning.Versions {
+		if trimmed, ok := strings.CutSuffix(root, "/"+v.Name); ok {
+			root = trimmed
+			break
+		}
+	}
+	return root
+}
+
+// VersionPath returns the path prefix of the named API version
+func (h HTTPConfig) VersionPath(name string) string {
+	return h.APIRoot() + "/" + name
+}
+
+// TLSConfig holds HTTPS and mutual TLS configuration. The certificate and key
+// come from files or, when no files are set, from JSONConfig.Certificates.
+type TLSConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	CertFile       string   `yaml:"cert_file"`
+	KeyFile        string   `yaml:"key_file"`
+	CertName       string   `yaml:"cert_name"`       // Certificate entry in the JSON config
+	KeyName        string   `yaml:"key_name"`        // Private key entry in the JSON config
+	MinVersion     string   `yaml:"min_version"`     // "1.2" or "1.3"
+	CipherSuites   []string `yaml:"cipher_suites"`   // IANA names; applies to TLS 1.2 only
+	ClientAuth     string   `yaml:"client_auth"`     // none, request, require, verify_if_given, require_and_verify
+	ClientCAFile   string   `yaml:"client_ca_file"`  // CA bundle used to verify client certificates
+	ClientCAName   string   `yaml:"client_ca_name"`  // CA bundle entry in the JSON config
+	ReloadInterval string   `yaml:"reload_interval"` // How often certificate files are checked for changes
+}
+
+// MongoDB startup policies
+const (
+	// StartupPolicyFailFast aborts startup when MongoDB is unreachable
+	StartupPolicyFailFast = "fail_fast"
+	// StartupPolicyDegraded starts without MongoDB and reconnects in the background
+	StartupPolicyDegraded = "degraded"
+)
+
 // AppConfig holds application-specific configuration
 type AppConfig struct {
 	MongoDB struct {
-		URI      string `yaml:"uri"`
-		Database string `yaml:"database"`
-		Timeout  string `yaml:"timeout"`
+		URI                     string `yaml:"uri"`
+		Database                string `yaml:"database"`
+		Timeout                 string `yaml:"timeout"`
+		StartupPolicy           string `yaml:"startup_policy"`            // fail_fast or degraded
+		ReconnectInitialBackoff string `yaml:"reconnect_initial_backoff"` // First wait between reconnect attempts in degraded mode
+		ReconnectMaxBackoff     string `yaml:"reconnect_max_backoff"`     // Upper bound of the reconnect backoff
 	} `yaml:"mongodb"`
-	JWTSecret          string `yaml:"jwt_secret"`
-	PasswordSaltRounds int    `yaml:"password_salt_rounds"`
-	JSONConfigPath     string `yaml:"json_config_path"`
+	JWTSecret          string            `yaml:"jwt_secret"`
+	PasswordSaltRounds int               `yaml:"password_salt_rounds"`
+	JSONConfigPath     string            `yaml:"json_config_path"`
+	Idempotency        IdempotencyConfig `yaml:"idempotency"`
+	Pagination         PaginationConfig  `yaml:"pagination"`
+	Features           FeaturesConfig    `yaml:"features"`
+	DefaultLanguage    string            `yaml:"default_language"` // Response language when Accept-Language matches no bundle
+}
+
+// IdempotencyConfig holds the Idempotency-Key store configuration
+type IdempotencyConfig struct {
+	TTL         string `yaml:"ttl"`          // How long keys and responses are kept
+	LockTimeout string `yaml:"lock_timeout"` // How long an in-flight key blocks duplicates
+	MaxBody     int64  `yaml:"max_body"`     // Largest body buffered to fingerprint a request, in bytes
+}
+
+// PaginationConfig holds the cursor pagination settings
+type PaginationConfig struct {
+	// CursorSecret signs cursor tokens; when empty a random key is used and
+	// cursors are only valid in the instance that issued them
+	CursorSecret string `yaml:"cursor_secret"`
+}
+
+// FeaturesConfig holds the feature flags declared in the YAML configuration
+type FeaturesConfig struct {
+	EnableMetrics         bool                  `yaml:"enable_metrics"`
+	EnableTracing         bool                  `yaml:"enable_tracing"`
+	MaintenanceMode       bool                  `yaml:"maintenance_mode"`
+	MaintenanceRetryAfter int                   `yaml:"maintenance_retry_after"` // Seconds sent in Retry-After
+	Flags                 map[string]FlagConfig `yaml:"flags"`
+}
+
+// FlagConfig declares a custom feature flag
+type FlagConfig struct {
+	Enabled             bool     `yaml:"enabled"`
+	Percentage          *int     `yaml:"percentage"` // Rollout percentage, 100 when omitted
+	Users               []string `yaml:"users"`
+	Tenants             []string `yaml:"tenants"`
+	AllowHeaderOverride bool     `yaml:"allow_header_override"`
+}
+
+// ObservabilityConfig holds metrics and tracing configuration
+type ObservabilityConfig struct {
+	Metrics MetricsConfig `yaml:"metrics"`
+	Tracing TracingConfig `yaml:"tracing"`
+}
+
+// TracingConfig holds the OpenTelemetry tracing configuration
+type TracingConfig struct {
+	Enabled           bool    `yaml:"enabled"`
+	ServiceName       string  `yaml:"service_name"`
+	CollectorEndpoint string  `yaml:"collector_endpoint"` // OTLP/HTTP traces URL, e.g. http://localhost:4318/v1/traces
+	SampleRatio       float64 `yaml:"sample_ratio"`       // Fraction of new traces sampled, 0-1
+}
+
+// MetricsConfig holds the Prometheus endpoint configuration
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Port    int    `yaml:"port"`
+	Path    string `yaml:"path"`
+}
+
+// HealthConfig holds health check configuration
+type HealthConfig struct {
+	Path          string   `yaml:"path"`
+	CheckInterval string   `yaml:"check_interval"` // How long check results are cached
+	Timeout       string   `yaml:"timeout"`        // Maximum duration of a single check
+	Integrations  []string `yaml:"integrations"`   // Integration names whose reachability is checked
+}
+
+// TimeoutsConfig holds timeouts shared across components
+type TimeoutsConfig struct {
+	Database   string `yaml:"database"`
+	HTTPClient string `yaml:"http_client"`
+	GRPCClient string `yaml:"grpc_client"`
+	Shutdown   string `yaml:"shutdown"`    // Total time allowed for a graceful shutdown
+	DrainDelay string `yaml:"drain_delay"` // Wait after readiness turns false, before servers stop accepting requests
+}
+
+// ModuleConfig holds the configuration of a business module
+type ModuleConfig struct {
+	Enabled *bool `yaml:"enabled"` // Modules are enabled unless set to false
+}
+
+// ModuleEnabled reports whether the named module is enabled. Modules missing
+// from the modules section are enabled.
+func (c *Config) ModuleEnabled(name string) bool {
+	m, ok := c.Modules[name]
+	if !ok || m.Enabled == nil {
+		return true
+	}
+	return *m.Enabled
+}
+
+// Route auth modes
+const (
+	// RouteAuthNone serves the route without authentication
+	RouteAuthNone = "none"
+	// RouteAuthJWT requires a valid HS256 bearer token signed with app.jwt_secret
+	RouteAuthJWT = "jwt"
+	// RouteAuthOptional verifies the bearer token when present
+	RouteAuthOptional = "optional"
+)
+
+// RoutePolicy tunes a route without code changes. Policies are keyed by
+// route name (its OpenAPI operationId) or by path template, optionally
+// prefixed by a method ("GET /users/{id}"); templates are relative to the
+// version path unless they include it.
+type RoutePolicy struct {
+	Enabled    *bool            `yaml:"enabled"`    // Disabled routes answer 404; enabled when omitted
+	Timeout    string           `yaml:"timeout"`    // Deadline of the request context, e.g. 5s
+	Auth       string           `yaml:"auth"`       // none, jwt or optional
+	Permission string           `yaml:"permission"` // Permission required in the token; implies auth jwt
+	RateLimit  *RateLimitPolicy `yaml:"rate_limit"`
+	MaxBody    int64            `yaml:"max_body"`  // Maximum request body in bytes; 0 means no limit
+	CacheTTL   string           `yaml:"cache_ttl"` // Cache-Control max-age of successful GET responses
+	// Headers set on the responses of the route, overriding the security
+	// headers; an empty value removes the header
+	Headers map[string]string `yaml:"headers"`
+}
+
+// RateLimitPolicy is a token bucket applied per key
+type RateLimitPolicy struct {
+	RPS   float64 `yaml:"rps"`   // Sustained requests per second
+	Burst int     `yaml:"burst"` // Requests allowed at once; defaults to rps
+	Key   string  `yaml:"key"`   // ip (default), user or route
+}
+
+// IsEnabled reports whether the route is served
+func (p RoutePolicy) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
+}
+
+// AccessListsConfig declares named IP allow and deny lists. The JSON config
+// params "access_list.<name>.allow" and "access_list.<name>.deny"
+// (comma-separated) replace the YAML rules and are reloaded while running.
+type AccessListsConfig struct {
+	ReloadInterval string                      `yaml:"reload_interval"` // How often the JSON config file is checked for changes
+	Lists          map[string]AccessListConfig `yaml:"lists"`
+}
+
+// AccessListConfig is a named list of CIDR rules attached to a route group
+type AccessListConfig struct {
+	Allow []string `yaml:"allow"` // CIDRs or IPs; when set, other clients are rejected
+	Deny  []string `yaml:"deny"`  // CIDRs or IPs always rejected
+	Paths []string `yaml:"paths"` // Route group: path prefixes, with or without the version path
+}
+
+// AdminConfig holds configuration for the administrative endpoints
+type AdminConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"` // Listen address, e.g. 127.0.0.1:9091 or an internal interface
+	Token   string `yaml:"token"`   // Bearer token required by admin routes; admin routes are disabled when empty
+	// AccessList names an entry of access_lists applied to every admin route
+	AccessList string `yaml:"access_list"`
 }
 
 // LoadConfig reads configuration from YAML file, environment variables, and JSON config
@@ -98,6 +383,64 @@ func LoadConfig(configPath string) (*Config, error) {
 		config.App.MongoDB.Timeout = "10s"
 	}
 
+	if config.App.Idempotency.TTL == "" {
+		config.App.Idempotency.TTL = "24h"
+	}
+	if config.App.Idempotency.LockTimeout == "" {
+		config.App.Idempotency.LockTimeout = "1m"
+	}
+	if config.App.Idempotency.MaxBody <= 0 {
+		config.App.Idempotency.MaxBody = 1 << 20
+	}
+
+	if config.App.Features.MaintenanceRetryAfter <= 0 {
+		config.App.Features.MaintenanceRetryAfter = 300
+	}
+
+	if config.Observability.Metrics.Port == 0 {
+		config.Observability.Metrics.Port = 9090
+	}
+	if config.Observability.Metrics.Path == "" {
+		config.Observability.Metrics.Path = "/metrics"
+	}
+
+	if config.Observability.Tracing.SampleRatio <= 0 || config.Observability.Tracing.SampleRatio > 1 {
+		config.Observability.Tracing.SampleRatio = 1
+	}
+
+	if config.Health.CheckInterval == "" {
+		config.Health.CheckInterval = "30s"
+	}
+	if config.Health.Timeout == "" {
+		config.Health.Timeout = "5s"
+	}
+
+	if config.Admin.Address == "" {
+		config.Admin.Address = "127.0.0.1:9091"
+	}
+
+	switch config.App.MongoDB.StartupPolicy {
+	case "":
+		config.App.MongoDB.StartupPolicy = StartupPolicyFailFast
+	case StartupPolicyFailFast, StartupPolicyDegraded:
+	default:
+		return nil, fmt.Errorf("invalid app.mongodb.startup_policy %q", config.App.MongoDB.StartupPolicy)
+	}
+	if config.App.MongoDB.ReconnectInitialBackoff == "" {
+		config.App.MongoDB.ReconnectInitialBackoff = "1s"
+	}
+	if config.App.MongoDB.ReconnectMaxBackoff == "" {
+		config.App.MongoDB.ReconnectMaxBackoff = "30s"
+	}
+
+	if config.App.DefaultLanguage == "" {
+		config.App.DefaultLanguage = "en"
+	}
+
+	if config.Timeouts.Shutdown == "" {
+		config.Timeouts.Shutdown = "30s"
+	}
+
 	// Map MongoDB configuration from App.MongoDB to top-level fields
 	config.MongoURI = config.App.MongoDB.URI
 	config.MongoDB = config.App.MongoDB.Database
@@ -115,5 +458,225 @@ func LoadConfig(configPath string) (*Config, error) {
 	// Ensure it starts with a single slash and doesn't end with a slash
 	config.HTTP.BasePath = "/" + strings.Trim(config.HTTP.BasePath, "/")
 
+	if err := normalizeVersioning(&config.HTTP.Versioning); err != nil {
+		return nil, err
+	}
+	normalizeSecurityHeaders(&config.HTTP.Security)
+	for _, entry := range config.HTTP.TrustedProxies {
+		if _, err := ParseCIDR(entry); err != nil {
+			return nil, fmt.Errorf("invalid http.trusted_proxies entry %q: %w", entry, err)
+		}
+	}
+	if err := normalizeAccessLists(&config); err != nil {
+		return nil, err
+	}
+	if err := normalizeServerTimeouts(&config.HTTP); err != nil {
+		return nil, err
+	}
+	if err := normalizeRoutes(config.Routes, config.App.JWTSecret); err != nil {
+		return nil, err
+	}
+	if err := checkRouteTimeouts(config.Routes, config.HTTP.WriteTimeout); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
+
+// normalizeVersioning defaults to a single v1 version and validates names and dates
+func normalizeVersioning(v *VersioningConfig) error {
+	if len(v.Versions) == 0 {
+		v.Versions = []VersionConfig{{Name: "v1"}}
+	}
+
+	seen := make(map[string]bool, len(v.Versions))
+	for i := range v.Versions {
+		version := &v.Versions[i]
+		version.Name = strings.TrimSpace(version.Name)
+		if version.Name == "" || strings.ContainsAny(version.Name, "/ ") {
+			return fmt.Errorf("invalid http.versioning.versions[%d].name %q", i, version.Name)
+		}
+		if seen[version.Name] {
+			return fmt.Errorf("duplicate API version %q", version.Name)
+		}
+		seen[version.Name] = true
+
+		for field, value := range map[string]string{"deprecated_at": version.DeprecatedAt, "sunset": version.Sunset} {
+			if value == "" {
+				continue
+			}
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return fmt.Errorf("invalid %s of API version %s: %w", field, version.Name, err)
+			}
+		}
+	}
+
+	if v.Default == "" {
+		v.Default = v.Versions[0].Name
+	}
+	if !seen[v.Default] {
+		return fmt.Errorf("default API version %q is not declared", v.Default)
+	}
+	return nil
+}
+
+// normalizeSecurityHeaders fills the defaults of the security headers. The
+// docs policy allows the Swagger UI assets served from unpkg.com.
+func normalizeSecurityHeaders(c *SecurityHeadersConfig) {
+	if c.HSTSMaxAge <= 0 {
+		c.HSTSMaxAge = 31536000
+	}
+	if c.FrameOptions == "" {
+		c.FrameOptions = "DENY"
+	}
+	if c.ReferrerPolicy == "" {
+		c.ReferrerPolicy = "no-referrer"
+	}
+	if c.ContentSecurityPolicy == "" {
+		c.ContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+	}
+	if c.DocsContentSecurityPolicy == "" {
+		c.DocsContentSecurityPolicy = "default-src 'none'; script-src 'unsafe-inline' https://unpkg.com; " +
+			"style-src 'unsafe-inline' https://unpkg.com; img-src 'self' data: https:; connect-src 'self'; frame-ancestors 'none'"
+	}
+	if c.StripHeaders == nil {
+		c.StripHeaders = []string{"Server", "X-Powered-By"}
+	}
+}
+
+// normalizeAccessLists validates the rules of the access lists and the list
+// attached to the admin listener
+func normalizeAccessLists(config *Config) error {
+	if config.AccessLists.ReloadInterval == "" {
+		config.AccessLists.ReloadInterval = "30s"
+	}
+	if d, err := time.ParseDuration(config.AccessLists.ReloadInterval); err != nil || d <= 0 {
+		return fmt.Errorf("invalid access_lists.reload_interval %q", config.AccessLists.ReloadInterval)
+	}
+	for name, list := range config.AccessLists.Lists {
+		for _, entry := range append(slices.Clone(list.Allow), list.Deny...) {
+			if _, err := ParseCIDR(entry); err != nil {
+				return fmt.Errorf("invalid entry %q in access list %q: %w", entry, name, err)
+			}
+		}
+		for _, path := range list.Paths {
+			if !strings.HasPrefix(path, "/") {
+				return fmt.Errorf("path %q of access list %q must start with /", path, name)
+			}
+		}
+	}
+	// Reglas de los params del JSON config: access_list.<name>.allow / .deny
+	if config.JSONConfig != nil {
+		for _, p := range config.JSONConfig.Params {
+			rest, ok := strings.CutPrefix(p.Name, "access_list.")
+			if !ok {
+				continue
+			}
+			for _, entry := range strings.Split(p.Value, ",") {
+				if entry = strings.TrimSpace(entry); entry == "" {
+					continue
+				}
+				if _, err := ParseCIDR(entry); err != nil {
+					return fmt.Errorf("invalid entry %q in param %s.%s: %w", entry, "access_list", rest, err)
+				}
+			}
+		}
+	}
+	if name := config.Admin.AccessList; name != "" {
+		if _, ok := config.AccessLists.Lists[name]; !ok {
+			return fmt.Errorf("admin.access_list %q is not declared in access_lists", name)
+		}
+	}
+	return nil
+}
+
+// normalizeServerTimeouts fills the defaults of the server timeouts and
+// validates them
+func normalizeServerTimeouts(cfg *HTTPConfig) error {
+	for _, t := range []struct {
+		field string
+		value *string
+		def   string
+	}{
+		{"read_timeout", &cfg.ReadTimeout, "30s"},
+		{"write_timeout", &cfg.WriteTimeout, "30s"},
+		{"idle_timeout", &cfg.IdleTimeout, "120s"},
+	} {
+		if *t.value == "" {
+			*t.value = t.def
+		}
+		if d, err := time.ParseDuration(*t.value); err != nil || d <= 0 {
+			return fmt.Errorf("invalid http.%s %q", t.field, *t.value)
+		}
+	}
+	return nil
+}
+
+// checkRouteTimeouts rejects route timeouts that are not shorter than the
+// server write timeout: the connection would be closed before the 504
+func checkRouteTimeouts(routes map[string]RoutePolicy, writeTimeout string) error {
+	limit, _ := time.ParseDuration(writeTimeout)
+	for key, p := range routes {
+		if p.Timeout == "" {
+			continue
+		}
+		if d, _ := time.ParseDuration(p.Timeout); d >= limit {
+			return fmt.Errorf("timeout %s of route policy %q must be shorter than http.write_timeout %s", p.Timeout, key, writeTimeout)
+		}
+	}
+	return nil
+}
+
+// normalizeRoutes fills policy defaults and validates durations, auth modes
+// and rate limits
+func normalizeRoutes(routes map[string]RoutePolicy, jwtSecret string) error {
+	for key, p := range routes {
+		for field, value := range map[string]string{"timeout": p.Timeout, "cache_ttl": p.CacheTTL} {
+			if value == "" {
+				continue
+			}
+			if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+				return fmt.Errorf("invalid %s %q in route policy %q", field, value, key)
+			}
+		}
+
+		switch p.Auth {
+		case "":
+			p.Auth = RouteAuthNone
+			if p.Permission != "" {
+				p.Auth = RouteAuthJWT
+			}
+		case RouteAuthNone, RouteAuthJWT, RouteAuthOptional:
+		default:
+			return fmt.Errorf("invalid auth %q in route policy %q", p.Auth, key)
+		}
+		if p.Permission != "" && p.Auth != RouteAuthJWT {
+			return fmt.Errorf("route policy %q requires a permission without auth jwt", key)
+		}
+		if p.Auth != RouteAuthNone && jwtSecret == "" {
+			return fmt.Errorf("route policy %q requires app.jwt_secret", key)
+		}
+
+		if p.MaxBody < 0 {
+			return fmt.Errorf("invalid max_body %d in route policy %q", p.MaxBody, key)
+		}
+
+		if rl := p.RateLimit; rl != nil {
+			if rl.RPS <= 0 {
+				return fmt.Errorf("invalid rate_limit.rps in route policy %q", key)
+			}
+			if rl.Burst <= 0 {
+				rl.Burst = max(1, int(rl.RPS))
+			}
+			switch rl.Key {
+			case "":
+				rl.Key = "ip"
+			case "ip", "user", "route":
+			default:
+				return fmt.Errorf("invalid rate_limit.key %q in route policy %q", rl.Key, key)
+			}
+		}
+		routes[key] = p
+	}
+	return nil
+}
@@ -50,19 +50,7 @@ func LoadJSONConfig(filePath string) (*JSONConfig, error) {
 			return
 		}
 
-		data, err := os.ReadFile(filePath)
-		if err != nil {
-			loadErr = fmt.Errorf("error reading JSON config file: %w", err)
-			return
-		}
-
-		var config JSONConfig
-		if err := json.Unmarshal(data, &config); err != nil {
-			loadErr = fmt.Errorf("error unmarshaling JSON config: %w", err)
-			return
-		}
-
-		instance = &config
+		instance, loadErr = ReadJSONConfig(filePath)
 	})
 
 	if loadErr != nil {
@@ -72,6 +60,21 @@ func LoadJSONConfig(filePath string) (*JSONConfig, error) {
 	return instance, nil
 }
 
+// ReadJSONConfig reads the JSON configuration file without touching the
+// loaded instance, so components can pick up changes while running
+func ReadJSONConfig(filePath string) (*JSONConfig, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON config file: %w", err)
+	}
+
+	var config JSONConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON config: %w", err)
+	}
+	return &config, nil
+}
+
 // GetJSONConfig returns the loaded JSON configuration
 // Returns nil if not loaded yet
 func GetJSONConfig() *JSONConfig {
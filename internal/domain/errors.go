@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Errores de dominio de usuarios
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrInvalidUserID  = errors.New("invalid user ID")
+	ErrUserEmailTaken = errors.New("user email already registered")
+	ErrInvalidUser    = errors.New("invalid user data")
+	ErrUserModified   = errors.New("user was modified concurrently")
+)
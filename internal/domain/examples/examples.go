@@ -2,9 +2,12 @@ package examples
 
 import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/client"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -25,6 +28,8 @@ func GetAllCharacters(w http.ResponseWriter, r *http.Request) {
 
 	rc := client.NewRestClient(10 * time.Second)
 	req := &client.RequestData{
+		Context:     r.Context(),
+		Name:        "examples.one",
 		Host:        integrationDomain,
 		Port:        integrationPortInt,
 		UseHTTPS:    true,
@@ -38,23 +43,21 @@ func GetAllCharacters(w http.ResponseWriter, r *http.Request) {
 
 	respBody, status, err := rc.Get(req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Error making request: %v", err), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("error making request: %w", err)))
 		return
 	}
 
-	fmt.Printf("Status: %d\n", status)
-
 	if status != http.StatusOK {
-		http.Error(w, fmt.Sprintf("Unexpected status code: %d", status), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("unexpected status code: %d", status)))
 		return
 	}
 
 	var jsonData interface{}
 	if err := json.Unmarshal(respBody, &jsonData); err != nil {
-		http.Error(w, fmt.Sprintf("Error parsing JSON response: %v", err), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("error parsing JSON response: %w", err)))
 		return
 	}
-	_ = utils.SendSuccess(w, "SUCCESS", "Get all data of Restful API", http.StatusOK, jsonData)
+	_ = utils.SendSuccess(w, "SUCCESS", "EXAMPLE_DATA_RETRIEVED", http.StatusOK, jsonData)
 }
 
 func GetAllPlanets(w http.ResponseWriter, r *http.Request) {
@@ -69,6 +72,8 @@ func GetAllPlanets(w http.ResponseWriter, r *http.Request) {
 
 	rc := client.NewRestClient(10 * time.Second)
 	req := &client.RequestData{
+		Context:     r.Context(),
+		Name:        "examples.one",
 		Host:        integrationDomain,
 		Port:        integrationPortInt,
 		UseHTTPS:    true,
@@ -82,21 +87,28 @@ func GetAllPlanets(w http.ResponseWriter, r *http.Request) {
 
 	respBody, status, err := rc.Get(req)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Error making request: %v", err), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("error making request: %w", err)))
 		return
 	}
 
-	fmt.Printf("Status: %d\n", status)
-
 	if status != http.StatusOK {
-		http.Error(w, fmt.Sprintf("Unexpected status code: %d", status), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("unexpected status code: %d", status)))
 		return
 	}
 
 	var jsonData interface{}
 	if err := json.Unmarshal(respBody, &jsonData); err != nil {
-		http.Error(w, fmt.Sprintf("Error parsing JSON response: %v", err), http.StatusInternalServerError)
+		_ = utils.WriteError(w, r, upstreamError(fmt.Errorf("error parsing JSON response: %w", err)))
 		return
 	}
-	_ = utils.SendSuccess(w, "SUCCESS", "Get all data of Restful API", http.StatusOK, jsonData)
+	_ = utils.SendSuccess(w, "SUCCESS", "EXAMPLE_DATA_RETRIEVED", http.StatusOK, jsonData)
+}
+
+// upstreamError mapea un fallo de la integración al catálogo: 504 si venció
+// el deadline de la petición y 502 en otro caso. La causa solo se registra.
+func upstreamError(err error) *apperrors.Error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return apperrors.New(apperrors.CodeGatewayTimeout, err)
+	}
+	return apperrors.New(apperrors.CodeBadGateway, err)
 }
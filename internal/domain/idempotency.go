@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyRecord is the stored outcome of a request sent with an
+// Idempotency-Key header. It maps to the onb-ptf-idempotency-keys collection.
+type IdempotencyRecord struct {
+	Key         string      `bson:"_id"`
+	Fingerprint string      `bson:"fingerprint"`
+	Completed   bool        `bson:"completed"`
+	StatusCode  int         `bson:"status_code,omitempty"`
+	Header      http.Header `bson:"header,omitempty"`
+	Body        []byte      `bson:"body,omitempty"`
+	DateCreated time.Time   `bson:"date_created"`
+	ExpiresAt   time.Time   `bson:"expires_at"`
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 // User represents a user in the system.
 // This is a domain entity that maps to the onb-ptf-users collection.
@@ -11,3 +14,19 @@ type User struct {
 	DateCreated time.Time `bson:"date_created"`
 	DateUpdated time.Time `bson:"updated_created"`
 }
+
+// Version identifica la revisión del usuario a partir de su fecha de
+// actualización, con la precisión de milisegundos con la que la guarda MongoDB
+func (u *User) Version() string {
+	return strconv.FormatInt(u.DateUpdated.UnixMilli(), 10)
+}
+
+// ParseVersion devuelve la fecha de actualización de una versión obtenida con
+// Version
+func ParseVersion(version string) (time.Time, bool) {
+	ms, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms).UTC(), true
+}
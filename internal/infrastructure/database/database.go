@@ -10,7 +10,10 @@ import (
 
 	"api-ptf-core-business-orchestrator-go-ms/internal/config"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/tracing"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
@@ -29,77 +32,136 @@ type Database struct {
 	mu     sync.RWMutex
 }
 
+// ErrUnavailable is returned while MongoDB cannot be reached
+var ErrUnavailable = errors.New("database is unavailable")
+
 var (
-	once        sync.Once
-	instance    *Database
-	instanceErr error
+	instanceMu sync.Mutex
+	instance   *Database
 )
 
-// NewDatabase creates a new database connection instance
+// NewDatabase returns the shared database connection, connecting on the first
+// call. A failed attempt is not cached, so a later call connects again.
 func NewDatabase(cfg *config.Config) (*Database, error) {
-	once.Do(func() {
-		mongoCfg := cfg.App.MongoDB
-		if mongoCfg.URI == "" {
-			instanceErr = errors.New("MongoDB URI is not configured")
-			return
-		}
-
-		// Parse timeout duration
-		timeout, err := time.ParseDuration(mongoCfg.Timeout)
-		if err != nil {
-			timeout, _ = time.ParseDuration(defaultTimeout)
-		}
+	instanceMu.Lock()
+	defer instanceMu.Unlock()
 
-		logger.Log.Info("Connecting to MongoDB...",
-			zap.String("uri", mongoCfg.URI),
-			zap.String("database", mongoCfg.Database),
-			zap.String("timeout", mongoCfg.Timeout),
-		)
-		clientOptions := options.Client().
-			ApplyURI(mongoCfg.URI).
-			SetMinPoolSize(defaultMinPoolSize).
-			SetMaxPoolSize(defaultMaxPoolSize).
-			SetServerSelectionTimeout(timeout).
-			SetConnectTimeout(timeout).
-			SetTLSConfig(&tls.Config{
-				InsecureSkipVerify: true, // Keep TLS verification disabled as per original
-			})
-
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-
-		client, err := mongo.Connect(ctx, clientOptions)
-		if err != nil {
-			instanceErr = fmt.Errorf("failed to connect to MongoDB: %w", err)
-			return
-		}
+	if instance != nil {
+		return instance, nil
+	}
 
-		// Verify the connection with timeout
-		pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer pingCancel()
+	db, err := connect(cfg)
+	if err != nil {
+		return nil, err
+	}
+	instance = db
+	return instance, nil
+}
 
-		if err := client.Ping(pingCtx, nil); err != nil {
-			_ = client.Disconnect(context.Background())
-			instanceErr = fmt.Errorf("failed to ping MongoDB: %w", err)
-			return
+// Reconnect calls NewDatabase until it succeeds or ctx is done, doubling the
+// wait between attempts from initial up to max
+func Reconnect(ctx context.Context, cfg *config.Config, initial, max time.Duration) (*Database, error) {
+	backoff := initial
+	for attempt := 1; ; attempt++ {
+		db, err := NewDatabase(cfg)
+		if err == nil {
+			logger.Log.Info("Reconnected to MongoDB", zap.Int("attempt", attempt))
+			return db, nil
 		}
+		logger.Log.Warn("MongoDB still unavailable, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
 
-		instance = &Database{
-			client: client,
-			db:     client.Database(mongoCfg.Database),
-			config: cfg,
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
 		}
-	})
+		backoff = min(backoff*2, max)
+	}
+}
 
-	if instanceErr != nil {
-		return nil, instanceErr
+// connect opens a new client and verifies it with a ping
+func connect(cfg *config.Config) (*Database, error) {
+	mongoCfg := cfg.App.MongoDB
+	if mongoCfg.URI == "" {
+		return nil, errors.New("MongoDB URI is not configured")
 	}
 
-	if instance == nil {
-		return nil, errors.New("database instance is nil")
+	// Parse timeout duration
+	timeout, err := time.ParseDuration(mongoCfg.Timeout)
+	if err != nil {
+		timeout, _ = time.ParseDuration(defaultTimeout)
 	}
 
-	return instance, nil
+	logger.Log.Info("Connecting to MongoDB...",
+		zap.String("uri", mongoCfg.URI),
+		zap.String("database", mongoCfg.Database),
+		zap.String("timeout", mongoCfg.Timeout),
+	)
+	clientOptions := options.Client().
+		ApplyURI(mongoCfg.URI).
+		SetMinPoolSize(defaultMinPoolSize).
+		SetMaxPoolSize(defaultMaxPoolSize).
+		SetServerSelectionTimeout(timeout).
+		SetConnectTimeout(timeout).
+		SetPoolMonitor(metrics.MongoPoolMonitor()).
+		SetMonitor(commandMonitors(metrics.MongoCommandMonitor(), tracing.MongoCommandMonitor())).
+		SetTLSConfig(&tls.Config{
+			InsecureSkipVerify: true, // Keep TLS verification disabled as per original
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to connect to MongoDB: %w", ErrUnavailable, err)
+	}
+
+	// Verify the connection with timeout
+	pingCtx, pingCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer pingCancel()
+
+	if err := client.Ping(pingCtx, nil); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("%w: failed to ping MongoDB: %w", ErrUnavailable, err)
+	}
+
+	return &Database{
+		client: client,
+		db:     client.Database(mongoCfg.Database),
+		config: cfg,
+	}, nil
+}
+
+// commandMonitors fans out command events to several monitors
+func commandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			for _, m := range monitors {
+				if m.Started != nil {
+					m.Started(ctx, e)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			for _, m := range monitors {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, e)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			for _, m := range monitors {
+				if m.Failed != nil {
+					m.Failed(ctx, e)
+				}
+			}
+		},
+	}
 }
 
 // getUintConfig safely gets a uint value from config with a default fallback
@@ -110,6 +172,13 @@ func getUintConfig(value, defaultValue uint) uint {
 	return value
 }
 
+// Wrap returns a Database over an already connected MongoDB database, such
+// as one created by a test harness. It is not registered as the shared
+// instance.
+func Wrap(db *mongo.Database) *Database {
+	return &Database{client: db.Client(), db: db}
+}
+
 // GetCollection returns a handle for a specific collection
 func (d *Database) GetCollection(name string) *mongo.Collection {
 	d.mu.RLock()
@@ -134,3 +203,15 @@ func (d *Database) GetClient() *mongo.Client {
 	defer d.mu.RUnlock()
 	return d.client
 }
+
+// Ping verifies that the MongoDB deployment is reachable
+func (d *Database) Ping(ctx context.Context) error {
+	if d == nil {
+		return ErrUnavailable
+	}
+	client := d.GetClient()
+	if client == nil {
+		return errors.New("MongoDB client is not initialized")
+	}
+	return client.Ping(ctx, nil)
+}
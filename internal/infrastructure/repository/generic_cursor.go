@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CursorPage es una página de la paginación por cursor. Los cursores vacíos
+// indican que no hay más páginas en esa dirección.
+type CursorPage[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+}
+
+// cursorPosition es el contenido firmado de un cursor: la clave de orden y el
+// _id del último documento visto
+type cursorPosition struct {
+	Query string        `bson:"q"`
+	Value bson.RawValue `bson:"v"`
+	ID    bson.RawValue `bson:"id"`
+	// Back indica que el cursor pide la página anterior a la posición
+	Back bool `bson:"b,omitempty"`
+}
+
+// FindByCursor obtiene una página de los documentos que cumplen los filtros
+// de q usando paginación por clave (keyset): en lugar de saltar documentos,
+// continúa desde la posición del cursor, así el costo no crece con la página
+// y las inserciones concurrentes no desplazan los resultados.
+//
+// El orden admite un solo campo, que debería estar indexado, más _id como
+// desempate; sin orden se pagina por _id. token es el cursor recibido o vacío
+// para la primera página. Los cursores solo son válidos para la misma
+// colección, filtros y orden.
+func (r *GenericRepository[T]) FindByCursor(ctx context.Context, signer *cursor.Signer, q listquery.Query, token string, limit int64) (*CursorPage[T], error) {
+	key, desc, err := keysetSort(q)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := r.queryFingerprint(q, key, desc)
+
+	filter := queryFilter(q)
+	back := false
+	if token != "" {
+		pos, err := decodePosition(signer, token, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		back = pos.Back
+		filter = bson.M{"$and": bson.A{filter, keysetAfter(key, desc != back, pos)}}
+	}
+
+	// Hacia atrás se recorre en orden inverso y luego se invierte el resultado
+	dir := 1
+	if desc != back {
+		dir = -1
+	}
+	sort := bson.D{{Key: key, Value: dir}}
+	if key != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: dir})
+	}
+
+	// Se pide un documento extra para saber si hay más páginas
+	opts := options.Find().SetSort(sort).SetLimit(limit + 1)
+	results, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close(ctx)
+
+	var raws []bson.Raw
+	if err := results.All(ctx, &raws); err != nil {
+		return nil, err
+	}
+	more := int64(len(raws)) > limit
+	if more {
+		raws = raws[:limit]
+	}
+	if back {
+		slices.Reverse(raws)
+	}
+
+	page := &CursorPage[T]{Items: make([]T, 0, len(raws))}
+	for _, raw := range raws {
+		var item T
+		if err := bson.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+		page.Items = append(page.Items, item)
+	}
+	if len(raws) == 0 {
+		return page, nil
+	}
+
+	// Retrocediendo siempre hay página siguiente; avanzando, la hay si sobró
+	// un documento. Lo mismo, al revés, para la anterior.
+	if more || back {
+		if page.NextCursor, err = encodePosition(signer, fingerprint, key, raws[len(raws)-1], false); err != nil {
+			return nil, err
+		}
+	}
+	if (more && back) || (!back && token != "") {
+		if page.PrevCursor, err = encodePosition(signer, fingerprint, key, raws[0], true); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// keysetSort devuelve la columna y dirección de orden de q. Un _id final se
+// ignora porque siempre se usa como desempate.
+func keysetSort(q listquery.Query) (string, bool, error) {
+	sort := q.Sort
+	if n := len(sort); n > 1 && sort[n-1].Column == "_id" {
+		sort = sort[:n-1]
+	}
+	switch len(sort) {
+	case 0:
+		return "_id", false, nil
+	case 1:
+		return sort[0].Column, sort[0].Desc, nil
+	}
+	return "", false, fmt.Errorf("%w: cursor pagination supports a single sort field", listquery.ErrInvalidSort)
+}
+
+// keysetAfter filtra los documentos posteriores a pos en el orden de recorrido.
+// MongoDB ordena los nulos y campos ausentes antes que cualquier valor, pero
+// $gt/$lt no los comparan con otros tipos, así que el paso entre nulos y
+// valores tiene su propia rama: ascendiendo desde un nulo siguen todos los
+// valores y descendiendo desde un valor siguen todos los nulos.
+func keysetAfter(key string, descending bool, pos cursorPosition) bson.M {
+	op := "$gt"
+	if descending {
+		op = "$lt"
+	}
+	if key == "_id" {
+		return bson.M{"_id": bson.M{op: pos.ID}}
+	}
+	sameKey := bson.M{key: pos.Value, "_id": bson.M{op: pos.ID}}
+	if pos.Value.Type == bsontype.Null {
+		if descending {
+			return sameKey
+		}
+		return bson.M{"$or": bson.A{sameKey, bson.M{key: bson.M{"$ne": nil}}}}
+	}
+	branches := bson.A{bson.M{key: bson.M{op: pos.Value}}, sameKey}
+	if descending {
+		branches = append(branches, bson.M{key: nil})
+	}
+	return bson.M{"$or": branches}
+}
+
+// queryFingerprint identifica la colección, filtros y orden de un cursor
+func (r *GenericRepository[T]) queryFingerprint(q listquery.Query, key string, desc bool) string {
+	parts := make([]string, 0, len(q.Filters))
+	for _, f := range q.Filters {
+		parts = append(parts, fmt.Sprintf("%s %s %v", f.Column, f.Op, f.Value))
+	}
+	slices.Sort(parts)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%t|%s", r.collection.Name(), key, desc, strings.Join(parts, "|"))))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodePosition(signer *cursor.Signer, fingerprint, key string, doc bson.Raw, back bool) (string, error) {
+	pos := cursorPosition{Query: fingerprint, ID: doc.Lookup("_id"), Back: back}
+	value, err := doc.LookupErr(key)
+	if err != nil {
+		// Un campo ausente ordena como null
+		value = bson.RawValue{Type: bsontype.Null}
+	}
+	pos.Value = value
+	payload, err := bson.Marshal(pos)
+	if err != nil {
+		return "", err
+	}
+	return signer.Sign(payload), nil
+}
+
+func decodePosition(signer *cursor.Signer, token, fingerprint string) (cursorPosition, error) {
+	var pos cursorPosition
+	payload, err := signer.Verify(token)
+	if err != nil {
+		return pos, err
+	}
+	if err := bson.Unmarshal(payload, &pos); err != nil {
+		return pos, fmt.Errorf("%w: %v", cursor.ErrInvalidCursor, err)
+	}
+	if pos.Query != fingerprint {
+		return pos, fmt.Errorf("%w: cursor belongs to another query", cursor.ErrInvalidCursor)
+	}
+	return pos, nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestCursorPositionRoundTrip(t *testing.T) {
+	signer := cursor.NewSigner([]byte("secret"))
+	id := primitive.NewObjectID()
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	doc, err := bson.Marshal(bson.M{"_id": id, "date_created": created})
+	require.NoError(t, err)
+
+	token, err := encodePosition(signer, "fp", "date_created", doc, true)
+	require.NoError(t, err)
+
+	pos, err := decodePosition(signer, token, "fp")
+	require.NoError(t, err)
+	assert.True(t, pos.Back)
+	assert.Equal(t, id, pos.ID.ObjectID())
+	assert.True(t, created.Equal(pos.Value.Time()))
+
+	_, err = decodePosition(signer, token, "other-query")
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+	_, err = decodePosition(cursor.NewSigner([]byte("other")), token, "fp")
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+	_, err = decodePosition(signer, token[1:], "fp")
+	assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+}
+
+func TestKeysetSort(t *testing.T) {
+	key, desc, err := keysetSort(listquery.Query{})
+	require.NoError(t, err)
+	assert.Equal(t, "_id", key)
+	assert.False(t, desc)
+
+	key, desc, err = keysetSort(listquery.Query{Sort: []listquery.Sort{{Column: "date_created", Desc: true}, {Column: "_id"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "date_created", key)
+	assert.True(t, desc)
+
+	_, _, err = keysetSort(listquery.Query{Sort: []listquery.Sort{{Column: "email"}, {Column: "date_created"}}})
+	assert.ErrorIs(t, err, listquery.ErrInvalidSort)
+}
+
+func TestKeysetAfterNulls(t *testing.T) {
+	id := primitive.NewObjectID()
+	_, rawID, _ := bson.MarshalValue(id)
+	idValue := bson.RawValue{Type: bsontype.ObjectID, Value: rawID}
+	null := cursorPosition{Value: bson.RawValue{Type: bsontype.Null}, ID: idValue}
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	_, rawTime, _ := bson.MarshalValue(created)
+	value := cursorPosition{Value: bson.RawValue{Type: bsontype.DateTime, Value: rawTime}, ID: idValue}
+
+	sameNull := bson.M{"date_created": null.Value, "_id": bson.M{"$gt": idValue}}
+	tests := []struct {
+		name       string
+		descending bool
+		pos        cursorPosition
+		want       bson.M
+	}{
+		{
+			// Los nulos van primero: después de ellos siguen todos los valores
+			name: "AscendingFromNull",
+			pos:  null,
+			want: bson.M{"$or": bson.A{sameNull, bson.M{"date_created": bson.M{"$ne": nil}}}},
+		},
+		{
+			name:       "DescendingFromNull",
+			descending: true,
+			pos:        null,
+			want:       bson.M{"date_created": null.Value, "_id": bson.M{"$lt": idValue}},
+		},
+		{
+			name: "AscendingFromValue",
+			pos:  value,
+			want: bson.M{"$or": bson.A{
+				bson.M{"date_created": bson.M{"$gt": value.Value}},
+				bson.M{"date_created": value.Value, "_id": bson.M{"$gt": idValue}},
+			}},
+		},
+		{
+			// Los nulos van al final: siguen a cualquier valor
+			name:       "DescendingFromValue",
+			descending: true,
+			pos:        value,
+			want: bson.M{"$or": bson.A{
+				bson.M{"date_created": bson.M{"$lt": value.Value}},
+				bson.M{"date_created": value.Value, "_id": bson.M{"$lt": idValue}},
+				bson.M{"date_created": nil},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, keysetAfter("date_created", tt.descending, tt.pos))
+		})
+	}
+}
+
+type cursorItem struct {
+	ID primitive.ObjectID `bson:"_id"`
+}
+
+func TestFindByCursor(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	signer := cursor.NewSigner([]byte("secret"))
+	q := listquery.Query{Sort: []listquery.Sort{{Column: "_id"}}}
+
+	ids := make([]primitive.ObjectID, 4)
+	docs := make([]bson.D, 4)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+		docs[i] = bson.D{{Key: "_id", Value: ids[i]}}
+	}
+
+	// token devuelve un cursor de la consulta de q en la posición de doc
+	token := func(mt *mtest.T, r *GenericRepository[cursorItem], doc bson.D, back bool) string {
+		raw, err := bson.Marshal(doc)
+		require.NoError(mt, err)
+		key, desc, _ := keysetSort(q)
+		tok, err := encodePosition(signer, r.queryFingerprint(q, key, desc), key, raw, back)
+		require.NoError(mt, err)
+		return tok
+	}
+	// position decodifica un cursor de la página
+	position := func(mt *mtest.T, r *GenericRepository[cursorItem], tok string) cursorPosition {
+		key, desc, _ := keysetSort(q)
+		pos, err := decodePosition(signer, tok, r.queryFingerprint(q, key, desc))
+		require.NoError(mt, err)
+		return pos
+	}
+	itemIDs := func(page *CursorPage[cursorItem]) []primitive.ObjectID {
+		out := make([]primitive.ObjectID, 0, len(page.Items))
+		for _, item := range page.Items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+	// findCommand devuelve el último find enviado
+	findCommand := func(mt *mtest.T) bson.Raw {
+		var cmd bson.Raw
+		for _, e := range mt.GetAllStartedEvents() {
+			if e.CommandName == "find" {
+				cmd = e.Command
+			}
+		}
+		require.NotNil(mt, cmd)
+		return cmd
+	}
+
+	mt.Run("FirstPageWithMore", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch, docs[0], docs[1], docs[2]))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, "", 2)
+		require.NoError(mt, err)
+		assert.Equal(mt, ids[:2], itemIDs(page))
+		assert.Empty(mt, page.PrevCursor, "the first page has no previous page")
+		require.NotEmpty(mt, page.NextCursor)
+		next := position(mt, r, page.NextCursor)
+		assert.False(mt, next.Back)
+		assert.Equal(mt, ids[1], next.ID.ObjectID())
+		assert.Equal(mt, int64(3), findCommand(mt).Lookup("limit").AsInt64())
+	})
+
+	mt.Run("FirstPageWithoutMore", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch, docs[0]))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, "", 2)
+		require.NoError(mt, err)
+		assert.Equal(mt, ids[:1], itemIDs(page))
+		assert.Empty(mt, page.NextCursor)
+		assert.Empty(mt, page.PrevCursor)
+	})
+
+	mt.Run("ForwardToLastPage", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch, docs[2]))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, token(mt, r, docs[1], false), 2)
+		require.NoError(mt, err)
+		assert.Equal(mt, ids[2:3], itemIDs(page))
+		assert.Empty(mt, page.NextCursor, "no extra document: last page")
+		require.NotEmpty(mt, page.PrevCursor, "a page reached with a cursor has a previous page")
+		prev := position(mt, r, page.PrevCursor)
+		assert.True(mt, prev.Back)
+		assert.Equal(mt, ids[2], prev.ID.ObjectID())
+
+		cmd := findCommand(mt)
+		assert.Equal(mt, int32(1), cmd.Lookup("sort", "_id").Int32())
+		after := cmd.Lookup("filter", "$and").Array().Index(1).Value().Document()
+		assert.Equal(mt, ids[1], after.Lookup("_id", "$gt").ObjectID())
+	})
+
+	mt.Run("BackWithMore", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		// Hacia atrás MongoDB devuelve los documentos en orden inverso
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch, docs[2], docs[1], docs[0]))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, token(mt, r, docs[3], true), 2)
+		require.NoError(mt, err)
+		assert.Equal(mt, []primitive.ObjectID{ids[1], ids[2]}, itemIDs(page))
+		require.NotEmpty(mt, page.NextCursor, "going back there is always a next page")
+		require.NotEmpty(mt, page.PrevCursor, "the extra document means an earlier page")
+		next, prev := position(mt, r, page.NextCursor), position(mt, r, page.PrevCursor)
+		assert.False(mt, next.Back)
+		assert.Equal(mt, ids[2], next.ID.ObjectID())
+		assert.True(mt, prev.Back)
+		assert.Equal(mt, ids[1], prev.ID.ObjectID())
+
+		cmd := findCommand(mt)
+		assert.Equal(mt, int32(-1), cmd.Lookup("sort", "_id").Int32())
+		after := cmd.Lookup("filter", "$and").Array().Index(1).Value().Document()
+		assert.Equal(mt, ids[3], after.Lookup("_id", "$lt").ObjectID())
+	})
+
+	mt.Run("BackToFirstPage", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch, docs[1], docs[0]))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, token(mt, r, docs[2], true), 2)
+		require.NoError(mt, err)
+		assert.Equal(mt, ids[:2], itemIDs(page))
+		assert.NotEmpty(mt, page.NextCursor)
+		assert.Empty(mt, page.PrevCursor, "back at the first page")
+	})
+
+	mt.Run("EmptyPage", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.items", mtest.FirstBatch))
+
+		page, err := r.FindByCursor(context.Background(), signer, q, token(mt, r, docs[3], false), 2)
+		require.NoError(mt, err)
+		assert.Empty(mt, page.Items)
+		assert.Empty(mt, page.NextCursor)
+		assert.Empty(mt, page.PrevCursor)
+	})
+
+	mt.Run("CursorOfAnotherQuery", func(mt *mtest.T) {
+		r := NewGenericRepository[cursorItem](database.Wrap(mt.DB), "items")
+		other := listquery.Query{Sort: []listquery.Sort{{Column: "_id", Desc: true}}}
+
+		_, err := r.FindByCursor(context.Background(), signer, other, token(mt, r, docs[1], false), 2)
+		assert.ErrorIs(mt, err, cursor.ErrInvalidCursor)
+	})
+}
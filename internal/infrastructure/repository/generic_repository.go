@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -121,6 +122,108 @@ func (r *GenericRepository[T]) FindAll(ctx context.Context, page, limit int64) (
 	return results, nil
 }
 
+// FindPage obtiene una página de los documentos que cumplen los filtros de q,
+// en el orden de q. El _id desempata el orden para que las páginas sean estables.
+func (r *GenericRepository[T]) FindPage(ctx context.Context, q listquery.Query, page, limit int64) ([]T, error) {
+	skip, err := listquery.Offset(page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find()
+	opts.SetSort(querySort(q))
+	opts.SetSkip(skip)
+	opts.SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, queryFilter(q), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	results := []T{}
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountMatching devuelve el número de documentos que cumplen los filtros de q
+func (r *GenericRepository[T]) CountMatching(ctx context.Context, q listquery.Query) (int64, error) {
+	return r.collection.CountDocuments(ctx, queryFilter(q))
+}
+
+// queryFilter traduce los filtros a un documento de MongoDB. Los filtros de
+// una misma columna se combinan en un único subdocumento: las igualdades
+// repetidas pasan a $in y de los rangos repetidos se queda el más estricto.
+func queryFilter(q listquery.Query) bson.M {
+	filter := bson.M{}
+	equals := map[string][]any{}
+	for _, f := range q.Filters {
+		if f.Op == listquery.OpEq {
+			equals[f.Column] = append(equals[f.Column], f.Value)
+			continue
+		}
+		cond := columnFilter(filter, f.Column)
+		op := "$" + f.Op
+		if prev, ok := cond[op]; !ok || tighter(f.Op, f.Value, prev) {
+			cond[op] = f.Value
+		}
+	}
+	for column, values := range equals {
+		cond := columnFilter(filter, column)
+		if len(values) == 1 {
+			cond["$eq"] = values[0]
+		} else {
+			cond["$in"] = values
+		}
+	}
+	return filter
+}
+
+func columnFilter(filter bson.M, column string) bson.M {
+	cond, _ := filter[column].(bson.M)
+	if cond == nil {
+		cond = bson.M{}
+		filter[column] = cond
+	}
+	return cond
+}
+
+// tighter indica si el límite v restringe más que prev para el operador op
+func tighter(op string, v, prev any) bool {
+	a, okA := v.(time.Time)
+	b, okB := prev.(time.Time)
+	if !okA || !okB {
+		return false
+	}
+	switch op {
+	case listquery.OpGt, listquery.OpGte:
+		return a.After(b)
+	case listquery.OpLt, listquery.OpLte:
+		return a.Before(b)
+	}
+	return false
+}
+
+// querySort traduce el orden de q y agrega _id como desempate
+func querySort(q listquery.Query) bson.D {
+	sort := make(bson.D, 0, len(q.Sort)+1)
+	hasID := false
+	for _, s := range q.Sort {
+		dir := 1
+		if s.Desc {
+			dir = -1
+		}
+		sort = append(sort, bson.E{Key: s.Column, Value: dir})
+		hasID = hasID || s.Column == "_id"
+	}
+	if !hasID {
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	}
+	return sort
+}
+
 // Count devuelve el número total de documentos en la colección
 func (r *GenericRepository[T]) Count(ctx context.Context) (int64, error) {
 	return r.collection.CountDocuments(ctx, bson.M{})
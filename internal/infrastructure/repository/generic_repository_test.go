@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestQueryFilter(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filters []listquery.Filter
+		want    bson.M
+	}{
+		{
+			name: "Range",
+			filters: []listquery.Filter{
+				{Column: "date_created", Op: listquery.OpGte, Value: jan},
+				{Column: "date_created", Op: listquery.OpLt, Value: mar},
+			},
+			want: bson.M{"date_created": bson.M{"$gte": jan, "$lt": mar}},
+		},
+		{
+			name: "RepeatedEqualityIsIn",
+			filters: []listquery.Filter{
+				{Column: "email", Op: listquery.OpEq, Value: "a@b.co"},
+				{Column: "email", Op: listquery.OpEq, Value: "c@d.co"},
+			},
+			want: bson.M{"email": bson.M{"$in": []any{"a@b.co", "c@d.co"}}},
+		},
+		{
+			name: "RepeatedRangeKeepsTightest",
+			filters: []listquery.Filter{
+				{Column: "date_created", Op: listquery.OpGte, Value: feb},
+				{Column: "date_created", Op: listquery.OpGte, Value: jan},
+				{Column: "date_created", Op: listquery.OpLte, Value: mar},
+				{Column: "date_created", Op: listquery.OpLte, Value: feb},
+			},
+			want: bson.M{"date_created": bson.M{"$gte": feb, "$lte": feb}},
+		},
+		{
+			name: "EqualityAndRange",
+			filters: []listquery.Filter{
+				{Column: "date_created", Op: listquery.OpEq, Value: feb},
+				{Column: "date_created", Op: listquery.OpGt, Value: jan},
+				{Column: "email", Op: listquery.OpEq, Value: "a@b.co"},
+			},
+			want: bson.M{
+				"date_created": bson.M{"$eq": feb, "$gt": jan},
+				"email":        bson.M{"$eq": "a@b.co"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, queryFilter(listquery.Query{Filters: tt.filters}))
+		})
+	}
+}
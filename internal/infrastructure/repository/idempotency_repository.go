@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const idempotencyCollection = "onb-ptf-idempotency-keys"
+
+// MongoIdempotencyRepository stores idempotency keys and their responses in
+// MongoDB. Documents expire through a TTL index on expires_at.
+type MongoIdempotencyRepository struct {
+	collection *mongo.Collection
+	ttl        time.Duration
+	lockTTL    time.Duration
+}
+
+// NewMongoIdempotencyRepository creates the repository and ensures its TTL index.
+// ttl is how long a key is remembered; lockTTL is how long an in-flight key
+// blocks duplicates before it is considered abandoned.
+func NewMongoIdempotencyRepository(db *database.Database, ttl, lockTTL time.Duration) *MongoIdempotencyRepository {
+	r := &MongoIdempotencyRepository{
+		collection: db.GetCollection(idempotencyCollection),
+		ttl:        ttl,
+		lockTTL:    lockTTL,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.EnsureIndexes(ctx); err != nil {
+		logger.Log.Warn("Failed to create idempotency TTL index", zap.Error(err))
+	}
+
+	return r
+}
+
+// EnsureIndexes creates the TTL index used to expire old keys
+func (r *MongoIdempotencyRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).SetName("expires_at_ttl"),
+	})
+	return err
+}
+
+// Reserve stores an in-flight record for key. When the key already exists it
+// returns the stored record and false instead.
+func (r *MongoIdempotencyRepository) Reserve(ctx context.Context, key, fingerprint string) (*domain.IdempotencyRecord, bool, error) {
+	now := time.Now()
+	record := domain.IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		DateCreated: now,
+		ExpiresAt:   now.Add(r.ttl),
+	}
+
+	_, err := r.collection.InsertOne(ctx, record)
+	if err == nil {
+		return &record, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var existing domain.IdempotencyRecord
+	if err := r.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&existing); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			// Expired and removed between the insert and the lookup
+			return r.Reserve(ctx, key, fingerprint)
+		}
+		return nil, false, err
+	}
+
+	// TTL deletion runs periodically, so expired or abandoned keys may still be present
+	abandoned := !existing.Completed && now.Sub(existing.DateCreated) > r.lockTTL
+	if now.After(existing.ExpiresAt) || abandoned {
+		res, err := r.collection.ReplaceOne(ctx,
+			bson.M{"_id": key, "date_created": existing.DateCreated},
+			record,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		if res.ModifiedCount == 1 {
+			return &record, true, nil
+		}
+		// Someone else took over the key first
+		return r.Reserve(ctx, key, fingerprint)
+	}
+
+	return &existing, false, nil
+}
+
+// Complete stores the response produced for key
+func (r *MongoIdempotencyRepository) Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": key},
+		bson.M{"$set": bson.M{
+			"completed":   true,
+			"status_code": statusCode,
+			"header":      header,
+			"body":        body,
+		}},
+	)
+	return err
+}
+
+// Release removes an in-flight key so the request can be retried
+func (r *MongoIdempotencyRepository) Release(ctx context.Context, key string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": key, "completed": false})
+	return err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/container"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+)
+
+// RegisterProviders registra los repositorios en el contenedor. Cada
+// repositorio se construye una sola vez y se comparte entre los módulos.
+func RegisterProviders(c *container.Container) {
+	container.Provide(c, func(c *container.Container) (*MongoUserRepository, error) {
+		db, err := container.Resolve[*database.Database](c)
+		if err != nil {
+			return nil, err
+		}
+		return NewMongoUserRepository(db), nil
+	})
+	container.Provide(c, func(c *container.Container) (UserRepository, error) {
+		return container.Resolve[*MongoUserRepository](c)
+	})
+	container.Provide(c, func(c *container.Container) (*GenericRepository[domain.User], error) {
+		userRepo, err := container.Resolve[*MongoUserRepository](c)
+		if err != nil {
+			return nil, err
+		}
+		return userRepo.Repo, nil
+	})
+	container.Provide(c, func(c *container.Container) (*MongoIdempotencyRepository, error) {
+		db, err := container.Resolve[*database.Database](c)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := container.Resolve[*config.Config](c)
+		if err != nil {
+			return nil, err
+		}
+		return NewMongoIdempotencyRepository(db,
+			config.ParseDuration(cfg.App.Idempotency.TTL, 24*time.Hour),
+			config.ParseDuration(cfg.App.Idempotency.LockTimeout, time.Minute),
+		), nil
+	})
+	container.Provide(c, func(c *container.Container) (*cursor.Signer, error) {
+		cfg, err := container.Resolve[*config.Config](c)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.App.Pagination.CursorSecret == "" {
+			logger.Log.Warn("app.pagination.cursor_secret is not set, cursors are only valid in this instance")
+		}
+		return cursor.NewSigner([]byte(cfg.App.Pagination.CursorSecret)), nil
+	})
+}
@@ -15,9 +15,12 @@ import (
 
 // UserRepository define la interfaz para operaciones de datos de usuarios
 type UserRepository interface {
+	Create(ctx context.Context, user *domain.User) (string, error)
 	// Métodos adicionales
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, id string, user *domain.User, expected time.Time) error
+	Delete(ctx context.Context, id string, expected *time.Time) error
 }
 
 // MongoUserRepository is the MongoDB implementation of UserRepository
@@ -99,35 +102,72 @@ func (r *MongoUserRepository) FindAll(ctx context.Context, page, limit int64) ([
 	return users, nil
 }
 
-// Update updates an existing user
-func (r *MongoUserRepository) Update(ctx context.Context, id string, user *domain.User) error {
-	user.DateUpdated = time.Now()
-
+// Update updates the email, password and update date of an existing user
+// only while its update date is still expected, so concurrent writes are not
+// lost. It returns mongo.ErrNoDocuments when no user has the given ID and
+// update date.
+func (r *MongoUserRepository) Update(ctx context.Context, id string, user *domain.User, expected time.Time) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
+	user.DateUpdated = time.Now()
+	filter := versionFilter(objID, expected)
 
+	// Solo los campos editables; _id y date_created no se tocan
 	update := bson.M{
-		"$set": user,
+		"$set": bson.M{
+			"email":           user.Email,
+			"pass":            user.Password,
+			"updated_created": user.DateUpdated,
+		},
 	}
 
-	_, err = r.Repo.collection.UpdateByID(ctx, objID, update)
-	return err
+	result, err := r.Repo.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
-// Delete removes a user from the database
-func (r *MongoUserRepository) Delete(ctx context.Context, id string) error {
+// Delete removes a user from the database. When expected is not nil the user
+// is only removed while its update date is still expected. It returns
+// mongo.ErrNoDocuments when no user has the given ID and update date.
+func (r *MongoUserRepository) Delete(ctx context.Context, id string, expected *time.Time) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.Repo.collection.DeleteOne(ctx, bson.M{"_id": objID})
-	return err
+	filter := bson.M{"_id": objID}
+	if expected != nil {
+		filter = versionFilter(objID, *expected)
+	}
+	result, err := r.Repo.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
 // Count returns the total number of users in the database
 func (r *MongoUserRepository) Count(ctx context.Context) (int64, error) {
 	return r.Repo.collection.CountDocuments(ctx, bson.M{})
 }
+
+// versionFilter selecciona el usuario objID mientras su fecha de
+// actualización siga siendo expected
+func versionFilter(objID primitive.ObjectID, expected time.Time) bson.M {
+	filter := bson.M{"_id": objID, "updated_created": expected}
+	if expected.IsZero() {
+		// Los documentos sin fecha de actualización se leen como fecha cero
+		filter["updated_created"] = bson.M{"$in": bson.A{nil, expected}}
+	}
+	return filter
+}
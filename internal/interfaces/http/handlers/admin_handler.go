@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// runtimeStats summarizes the Go runtime state
+type runtimeStats struct {
+	GoVersion    string `json:"goVersion"`
+	NumGoroutine int    `json:"numGoroutine"`
+	NumCPU       int    `json:"numCpu"`
+	GOMAXPROCS   int    `json:"gomaxprocs"`
+	Memory       struct {
+		HeapAlloc    uint64 `json:"heapAlloc"`
+		HeapInuse    uint64 `json:"heapInuse"`
+		HeapObjects  uint64 `json:"heapObjects"`
+		StackInuse   uint64 `json:"stackInuse"`
+		Sys          uint64 `json:"sys"`
+		TotalAlloc   uint64 `json:"totalAlloc"`
+		NextGCTarget uint64 `json:"nextGcTarget"`
+	} `json:"memory"`
+	GC struct {
+		NumGC         int64     `json:"numGc"`
+		LastGC        time.Time `json:"lastGc,omitempty"`
+		PauseTotal    string    `json:"pauseTotal"`
+		RecentPauses  []string  `json:"recentPauses"`
+		GCCPUFraction float64   `json:"gcCpuFraction"`
+	} `json:"gc"`
+}
+
+// buildInfo is the information embedded by the Go toolchain at build time
+type buildInfo struct {
+	GoVersion string            `json:"goVersion"`
+	Path      string            `json:"path"`
+	Main      string            `json:"main"`
+	Version   string            `json:"version"`
+	Settings  map[string]string `json:"settings"`
+	Deps      map[string]string `json:"deps"`
+}
+
+// RuntimeStats handles GET /admin/runtime with memory and GC statistics
+func RuntimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var gc debug.GCStats
+	gc.PauseQuantiles = make([]time.Duration, 5)
+	debug.ReadGCStats(&gc)
+
+	stats := runtimeStats{
+		GoVersion:    runtime.Version(),
+		NumGoroutine: runtime.NumGoroutine(),
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+	}
+	stats.Memory.HeapAlloc = mem.HeapAlloc
+	stats.Memory.HeapInuse = mem.HeapInuse
+	stats.Memory.HeapObjects = mem.HeapObjects
+	stats.Memory.StackInuse = mem.StackInuse
+	stats.Memory.Sys = mem.Sys
+	stats.Memory.TotalAlloc = mem.TotalAlloc
+	stats.Memory.NextGCTarget = mem.NextGC
+
+	stats.GC.NumGC = gc.NumGC
+	stats.GC.LastGC = gc.LastGC
+	stats.GC.PauseTotal = gc.PauseTotal.String()
+	stats.GC.GCCPUFraction = mem.GCCPUFraction
+	for i, p := range gc.Pause {
+		if i == 10 {
+			break
+		}
+		stats.GC.RecentPauses = append(stats.GC.RecentPauses, p.String())
+	}
+
+	_ = utils.SendSuccess(w, "SUCCESS", "RUNTIME_STATS_RETRIEVED", http.StatusOK, stats)
+}
+
+// BuildInfo handles GET /admin/buildinfo with the data from debug.ReadBuildInfo
+func BuildInfo(w http.ResponseWriter, r *http.Request) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeNotFound, nil).WithMessage("BUILD_INFO_UNAVAILABLE"))
+		return
+	}
+
+	info := buildInfo{
+		GoVersion: bi.GoVersion,
+		Path:      bi.Path,
+		Main:      bi.Main.Path,
+		Version:   bi.Main.Version,
+		Settings:  make(map[string]string, len(bi.Settings)),
+		Deps:      make(map[string]string, len(bi.Deps)),
+	}
+	for _, s := range bi.Settings {
+		info.Settings[s.Key] = s.Value
+	}
+	for _, d := range bi.Deps {
+		info.Deps[d.Path] = d.Version
+	}
+
+	_ = utils.SendSuccess(w, "SUCCESS", "BUILD_INFO_RETRIEVED", http.StatusOK, info)
+}
+
+// GoroutineDump handles GET /admin/goroutines with the stack of every goroutine
+func GoroutineDump(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := pprof.Lookup("goroutine").WriteTo(w, 2); err != nil {
+		logger.FromContext(r.Context()).Error("Failed to write goroutine dump", zap.Error(err))
+	}
+}
+
+// HeapDump handles GET /admin/heapdump, streaming a full heap dump produced
+// by debug.WriteHeapDump. The world is stopped while the dump is written.
+func HeapDump(w http.ResponseWriter, r *http.Request) {
+	f, err := os.CreateTemp("", "heapdump-*")
+	if err != nil {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeInternal, fmt.Errorf("create heap dump file: %w", err)).WithMessage("HEAP_DUMP_FAILED"))
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	debug.WriteHeapDump(f.Fd())
+	if _, err := f.Seek(0, 0); err != nil {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeInternal, fmt.Errorf("read heap dump file: %w", err)).WithMessage("HEAP_DUMP_FAILED"))
+		return
+	}
+
+	name := fmt.Sprintf("heapdump-%s", time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	http.ServeContent(w, r, name, time.Now(), f)
+}
+
+// ForceGC handles POST /admin/gc, running a garbage collection and returning
+// memory to the operating system
+func ForceGC(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	debug.FreeOSMemory()
+	_ = utils.SendSuccess(w, "SUCCESS", "GC_COMPLETED", http.StatusOK, map[string]string{
+		"duration": time.Since(start).String(),
+	})
+}
+
+// RouteTable handles GET /admin/routes with the routes mounted on every
+// listener. The listener query parameter restricts it to one listener.
+func RouteTable(w http.ResponseWriter, r *http.Request, table *routetable.Table) {
+	routes := table.Routes()
+	if listener := r.URL.Query().Get("listener"); listener != "" {
+		filtered, ok := routes[listener]
+		if !ok {
+			err := fmt.Errorf("listener %q not found", listener)
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeNotFound, err).WithMessage("LISTENER_NOT_FOUND"))
+			return
+		}
+		routes = map[string][]routetable.Route{listener: filtered}
+	}
+
+	_ = utils.SendSuccess(w, "SUCCESS", "ROUTE_TABLE_RETRIEVED", http.StatusOK, routes)
+}
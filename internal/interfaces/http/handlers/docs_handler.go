@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/versioning"
+)
+
+// OpenAPIDocument serves the OpenAPI document of the API version serving the
+// request, generated from the registered routes
+func OpenAPIDocument(w http.ResponseWriter, r *http.Request, app *models.Application) {
+	cfg := app.Configs()
+	basePath := cfg.HTTP.BasePath
+	if v, ok := versioning.FromContext(r.Context()); ok {
+		basePath = v.Path
+	}
+	doc := app.OpenAPI().Document(openapi.Info{
+		Title:       cfg.AppName,
+		Version:     cfg.Version,
+		Description: cfg.Description,
+	}, basePath)
+
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeInternal, fmt.Errorf("encode OpenAPI document: %w", err)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// SwaggerUI serves the interactive documentation page
+func SwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(openapi.SwaggerUI)
+}
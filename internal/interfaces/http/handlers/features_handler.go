@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// FeaturesHandler exposes the feature flag store through the admin API
+type FeaturesHandler struct {
+	store *features.Store
+}
+
+func NewFeaturesHandler(store *features.Store) *FeaturesHandler {
+	return &FeaturesHandler{store: store}
+}
+
+// featureEvaluation is the result of evaluating a flag for a subject
+type featureEvaluation struct {
+	Flag     features.Flag `json:"flag"`
+	UserID   string        `json:"userId,omitempty"`
+	TenantID string        `json:"tenantId,omitempty"`
+	Enabled  bool          `json:"enabled"`
+}
+
+// SetFeatureRequest is the body of PUT /admin/features/{name}. Percentage
+// defaults to 100 when omitted, as in the YAML flag configuration.
+type SetFeatureRequest struct {
+	Enabled             bool     `json:"enabled"`
+	Percentage          *int     `json:"percentage,omitempty"`
+	Users               []string `json:"users,omitempty"`
+	Tenants             []string `json:"tenants,omitempty"`
+	AllowHeaderOverride bool     `json:"allowHeaderOverride"`
+}
+
+// ListFeatures handles GET /admin/features
+func (h *FeaturesHandler) ListFeatures(w http.ResponseWriter, r *http.Request) {
+	_ = utils.SendSuccess(w, "SUCCESS", "FEATURES_RETRIEVED", http.StatusOK, h.store.List())
+}
+
+// GetFeature handles GET /admin/features/{name}
+// Optional user and tenant query parameters evaluate the flag for that subject.
+func (h *FeaturesHandler) GetFeature(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	flag, ok := h.store.Get(name)
+	if !ok {
+		err := fmt.Errorf("feature %q not found", name)
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeNotFound, err).WithMessage("FEATURE_NOT_FOUND"))
+		return
+	}
+
+	ec := features.EvalContext{
+		UserID:   r.URL.Query().Get("user"),
+		TenantID: r.URL.Query().Get("tenant"),
+	}
+	_ = utils.SendSuccess(w, "SUCCESS", "FEATURE_RETRIEVED", http.StatusOK, featureEvaluation{
+		Flag:     flag,
+		UserID:   ec.UserID,
+		TenantID: ec.TenantID,
+		Enabled:  h.store.Evaluate(name, ec),
+	})
+}
+
+// SetFeature handles PUT /admin/features/{name}
+func (h *FeaturesHandler) SetFeature(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(mux.Vars(r)["name"])
+
+	var req SetFeatureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteError(w, r, utils.BodyError(err))
+		return
+	}
+	percentage := 100
+	if req.Percentage != nil {
+		percentage = *req.Percentage
+	}
+	if percentage < 0 || percentage > 100 {
+		err := fmt.Errorf("percentage %d out of range", percentage)
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeBadRequest, err).WithMessage("FEATURE_INVALID_PERCENTAGE"))
+		return
+	}
+
+	h.store.Set(features.Flag{
+		Name:                name,
+		Enabled:             req.Enabled,
+		Percentage:          percentage,
+		Users:               req.Users,
+		Tenants:             req.Tenants,
+		AllowHeaderOverride: req.AllowHeaderOverride,
+	})
+	flag, _ := h.store.Get(name)
+	_ = utils.SendSuccess(w, "FEATURE_UPDATED", "FEATURE_UPDATED", http.StatusOK, flag)
+}
+
+// ResetFeature handles DELETE /admin/features/{name}, restoring the configured value
+func (h *FeaturesHandler) ResetFeature(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	if !h.store.Reset(name) {
+		err := fmt.Errorf("feature %q has no runtime override", name)
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeNotFound, err).WithMessage("FEATURE_NO_OVERRIDE"))
+		return
+	}
+	flag, _ := h.store.Get(name)
+	_ = utils.SendSuccess(w, "FEATURE_RESET", "FEATURE_RESET", http.StatusOK, flag)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetFeaturePercentage(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		want   int
+	}{
+		{"Omitted", `{"enabled":true}`, http.StatusOK, 100},
+		{"Explicit", `{"enabled":true,"percentage":25}`, http.StatusOK, 25},
+		{"Zero", `{"enabled":true,"percentage":0}`, http.StatusOK, 0},
+		{"OutOfRange", `{"enabled":true,"percentage":101}`, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := features.NewStore(config.FeaturesConfig{}, nil)
+			r := mux.NewRouter()
+			r.HandleFunc("/admin/features/{name}", NewFeaturesHandler(store).SetFeature).Methods(http.MethodPut)
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/admin/features/beta", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.status, rr.Code)
+			flag, ok := store.Get("beta")
+			assert.Equal(t, tt.status == http.StatusOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, flag.Percentage)
+			}
+		})
+	}
+}
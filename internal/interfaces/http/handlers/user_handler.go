@@ -4,16 +4,21 @@ import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/application"
 	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
 	httpMiddleware "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/cursor"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/listquery"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
-	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
@@ -25,12 +30,48 @@ var (
 	ErrInvalidEmail     = errors.New("valid email is required")
 )
 
+// userErrors mapea los errores de dominio de usuarios al catálogo
+var userErrors = apperrors.Mapper{
+	domain.ErrUserNotFound:   apperrors.CodeUserNotFound,
+	domain.ErrInvalidUserID:  apperrors.CodeUserInvalidID,
+	domain.ErrUserEmailTaken: apperrors.CodeUserEmailTaken,
+	domain.ErrInvalidUser:    apperrors.CodeUserInvalid,
+	domain.ErrUserModified:   apperrors.CodePreconditionFailed,
+
+	httpMiddleware.ErrInvalidPageParam:  apperrors.CodeInvalidPage,
+	httpMiddleware.ErrInvalidLimitParam: apperrors.CodeInvalidLimit,
+	listquery.ErrInvalidFilter:          apperrors.CodeInvalidFilter,
+	listquery.ErrInvalidSort:            apperrors.CodeInvalidSort,
+	listquery.ErrInvalidPage:            apperrors.CodeInvalidPage,
+	cursor.ErrInvalidCursor:             apperrors.CodeInvalidCursor,
+}
+
 // CreateUserRequest represents the request body for creating a user
 type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"pass" validate:"required,min=6"`
 }
 
+// UpdateUserRequest represents the request body for replacing a user. The
+// password is optional; when omitted the current one is kept.
+type UpdateUserRequest struct {
+	Email    string  `json:"email" validate:"required,email"`
+	Password *string `json:"pass,omitempty" validate:"omitempty,min=6"`
+}
+
+// PatchUserRequest documents the fields accepted by PATCH /users/{id}; the
+// body is applied as a JSON Merge Patch (RFC 7396)
+type PatchUserRequest struct {
+	Email string `json:"email,omitempty"`
+	Pass  string `json:"pass,omitempty"`
+}
+
+// MergePatchContentType is the media type of JSON Merge Patch bodies
+const MergePatchContentType = "application/merge-patch+json"
+
+// patchableUserFields son los campos que un merge patch puede modificar
+var patchableUserFields = map[string]bool{"email": true, "pass": true}
+
 type UserHandler struct {
 	userService *application.UserService
 }
@@ -74,7 +115,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 
 	if userID == "" {
 		logger.Warn("Empty user ID provided")
-		_ = utils.BadRequest(w, "User ID is required")
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUserInvalidID, nil).WithMessage("USER_ID_REQUIRED"))
 		return
 	}
 
@@ -84,14 +125,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	dbDuration := time.Since(dbStart)
 
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			logger.Warn("User not found")
-			_ = utils.NotFound(w, "User not found")
-			return
-		}
-
-		logger.Error("Failed to fetch user", zap.Error(err))
-		_ = utils.InternalServerError(w, "Failed to fetch user: "+err.Error())
+		_ = utils.WriteError(w, r, userErrors.Map(err))
 		return
 	}
 
@@ -103,7 +137,8 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 		zap.Duration("total_duration", time.Since(start)),
 	)
 
-	_ = utils.SendSuccess(w, "SUCCESS", "User retrieved successfully", http.StatusOK, user)
+	w.Header().Set("ETag", httpMiddleware.FormatETag(user.Version(), false))
+	_ = utils.SendSuccess(w, "SUCCESS", "USER_RETRIEVED", http.StatusOK, user)
 }
 
 // CreateUser handles POST /api/v1/users
@@ -115,6 +150,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 // @Param user body domain.User true "User data"
 // @Success 201 {object} domain.User
 // @Failure 400 {object} utils.Response
+// @Failure 409 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /users [post]
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -131,8 +167,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	var req CreateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		logger.Error("Failed to decode request body", zap.Error(err))
-		_ = utils.BadRequest(w, "Invalid request body")
+		_ = utils.WriteError(w, r, utils.BodyError(err))
 		return
 	}
 
@@ -151,8 +186,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	dbDuration := time.Since(dbStart)
 
 	if err != nil {
-		logger.Error("Failed to create user", zap.Error(err))
-		_ = utils.InternalServerError(w, "Failed to create user: "+err.Error())
+		_ = utils.WriteError(w, r, userErrors.Map(err))
 		return
 	}
 
@@ -165,26 +199,51 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		zap.Duration("total_duration", time.Since(start)),
 	)
 
-	_ = utils.SendSuccess(w, "USER_CREATED", "User created successfully", http.StatusCreated, createdUser)
+	w.Header().Set("ETag", httpMiddleware.FormatETag(createdUser.Version(), false))
+	_ = utils.SendSuccess(w, "USER_CREATED", "USER_CREATED", http.StatusCreated, createdUser)
 }
 
 // ListUsersResponse represents the response structure for the ListUsers endpoint
 type ListUsersResponse struct {
 	Data       interface{} `json:"data"`
 	Pagination struct {
-		Page  int64 `json:"page"`
-		Limit int64 `json:"limit"`
-		Total int64 `json:"total,omitempty"`
+		Page  int64  `json:"page,omitempty"`
+		Limit int64  `json:"limit"`
+		Total *int64 `json:"total,omitempty"`
+		// Cursor mode only; empty when there is no page in that direction
+		NextCursor string `json:"next_cursor,omitempty"`
+		PrevCursor string `json:"prev_cursor,omitempty"`
 	} `json:"pagination"`
+	Links PageLinks `json:"links"`
+}
+
+// PageLinks are the relative URLs of the current, next and previous pages
+type PageLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// userListSchema es la lista blanca de filtros y orden de GET /users
+var userListSchema = listquery.Schema{
+	Fields: []listquery.Field{
+		{Name: "email", Kind: listquery.String, Filter: true, Sort: true},
+		{Name: "date_created", Kind: listquery.Time, Filter: true, Sort: true},
+	},
+	DefaultSort: []listquery.Sort{{Column: "date_created", Desc: true}},
 }
 
 // ListUsers handles GET /api/v1/users
 // @Summary List all users with pagination
-// @Description Get a paginated list of users
+// @Description Get a paginated, filtered and sorted list of users
 // @Tags users
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Number of items per page (max: 100, default: 10)"
+// @Param filter[email] query string false "Exact email"
+// @Param filter[date_created][gte] query string false "Created at or after (RFC 3339 or YYYY-MM-DD); also gt, lt, lte"
+// @Param sort query string false "Comma-separated fields, - for descending (default: -date_created)"
+// @Param cursor query string false "Cursor pagination: empty for the first page, then next_cursor or prev_cursor"
 // @Success 200 {object} ListUsersResponse
 // @Failure 400 {object} utils.Response
 // @Failure 500 {object} utils.Response
@@ -205,38 +264,54 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	// Parse pagination parameters
 	page, limit, err := httpMiddleware.GetPaginationParams(r)
 	if err != nil {
-		logger.Warn("Invalid pagination parameters", zap.Error(err))
-		_ = utils.BadRequest(w, err.Error())
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	// Parse filters and sort against the whitelist
+	query, err := listquery.Parse(r.URL.Query(), userListSchema)
+	if err != nil {
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	// Con el parámetro cursor (vacío en la primera página) se pagina por clave
+	if r.URL.Query().Has("cursor") {
+		h.listUsersByCursor(w, r, query, limit, start)
 		return
 	}
 
 	logger = logger.With(
 		zap.Int64("page", page),
 		zap.Int64("limit", limit),
+		zap.Int("filters", len(query.Filters)),
 	)
 	logger.Info("Fetching users list")
 
-	// Get users using the service layer
+	// Get users and the total of the same filter using the service layer
 	dbStart := time.Now()
-	users, err := h.userService.ListUsers(r.Context(), page, limit)
+	users, total, err := h.userService.ListUsers(r.Context(), query, page, limit)
 	dbDuration := time.Since(dbStart)
 
 	if err != nil {
-		logger.Error("Failed to fetch users", zap.Error(err))
-		_ = utils.InternalServerError(w, "Failed to fetch users: "+err.Error())
+		_ = utils.WriteError(w, r, userErrors.Map(err))
 		return
 	}
 
-	// Get total count (implementation depends on your service)
-	total := int64(len(users)) // This should be replaced with actual count from service
-
 	// Prepare response
 	response := ListUsersResponse{
 		Data: users,
 	}
 	response.Pagination.Page = page
 	response.Pagination.Limit = limit
-	response.Pagination.Total = total
+	response.Pagination.Total = &total
+	response.Links.Self = pageLink(r, page)
+	if page*limit < total {
+		response.Links.Next = pageLink(r, page+1)
+	}
+	if page > 1 {
+		response.Links.Prev = pageLink(r, page-1)
+	}
 
 	// Log successful response
 	logger.Info("ListUsers completed",
@@ -249,5 +324,271 @@ func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		zap.Duration("total_duration", time.Since(start)),
 	)
 
-	_ = utils.SendSuccess(w, "SUCCESS", "Users retrieved successfully", http.StatusOK, response)
+	_ = utils.SendSuccess(w, "SUCCESS", "USERS_RETRIEVED", http.StatusOK, response)
+}
+
+// listUsersByCursor responde la página de usuarios que sigue al cursor de la
+// petición. No se cuenta el total para no recorrer la colección completa.
+func (h *UserHandler) listUsersByCursor(w http.ResponseWriter, r *http.Request, query listquery.Query, limit int64, start time.Time) {
+	logger := logger.FromContext(r.Context())
+	token := r.URL.Query().Get("cursor")
+
+	dbStart := time.Now()
+	page, err := h.userService.ListUsersByCursor(r.Context(), query, token, limit)
+	dbDuration := time.Since(dbStart)
+
+	if err != nil {
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	response := ListUsersResponse{
+		Data: page.Items,
+	}
+	response.Pagination.Limit = limit
+	response.Pagination.NextCursor = page.NextCursor
+	response.Pagination.PrevCursor = page.PrevCursor
+	response.Links.Self = cursorLink(r, token)
+	if page.NextCursor != "" {
+		response.Links.Next = cursorLink(r, page.NextCursor)
+	}
+	if page.PrevCursor != "" {
+		response.Links.Prev = cursorLink(r, page.PrevCursor)
+	}
+
+	logger.Info("ListUsers completed",
+		zap.String("request_id", httpMiddleware.GetRequestID(r.Context())),
+		zap.Int64("limit", limit),
+		zap.Int("users_count", len(page.Items)),
+		zap.Bool("has_next", page.NextCursor != ""),
+		zap.Duration("db_duration", dbDuration),
+		zap.Duration("total_duration", time.Since(start)),
+	)
+
+	_ = utils.SendSuccess(w, "SUCCESS", "USERS_RETRIEVED", http.StatusOK, response)
+}
+
+// cursorLink devuelve la URL relativa de la petición con otro cursor
+func cursorLink(r *http.Request, token string) string {
+	values := r.URL.Query()
+	values.Del("page")
+	values.Set("cursor", token)
+	return r.URL.Path + "?" + values.Encode()
+}
+
+// pageLink devuelve la URL relativa de la petición con otra página,
+// conservando filtros, orden y límite
+func pageLink(r *http.Request, page int64) string {
+	values := r.URL.Query()
+	values.Set("page", strconv.FormatInt(page, 10))
+	return r.URL.Path + "?" + values.Encode()
+}
+
+// UpdateUser handles PUT /api/v1/users/{id}
+// @Summary Replace a user
+// @Description Replace the email and, optionally, the password of a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param user body UpdateUserRequest true "User data"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 412 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /users/{id} [put]
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	requestID := httpMiddleware.GetRequestID(r.Context())
+	userID := strings.TrimSpace(mux.Vars(r)["id"])
+
+	logger := logger.FromContext(r.Context()).With(zap.String("user_id", userID))
+	logger.Info("UpdateUser started",
+		zap.String("request_id", requestID),
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+	)
+
+	var req UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		_ = utils.WriteError(w, r, utils.BodyError(err))
+		return
+	}
+	if strings.TrimSpace(req.Email) == "" {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUserInvalid, ErrInvalidEmail).WithMessage("USER_EMAIL_REQUIRED"))
+		return
+	}
+
+	dbStart := time.Now()
+	changes := application.UserChanges{Email: &req.Email, Password: req.Password, Version: ifMatchVersion(r)}
+	user, err := h.userService.UpdateUser(r.Context(), userID, changes)
+	dbDuration := time.Since(dbStart)
+
+	if err != nil {
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	logger.Info("UpdateUser completed",
+		zap.String("request_id", requestID),
+		zap.Duration("db_duration", dbDuration),
+		zap.Duration("total_duration", time.Since(start)),
+	)
+
+	w.Header().Set("ETag", httpMiddleware.FormatETag(user.Version(), false))
+	_ = utils.SendSuccess(w, "SUCCESS", "USER_UPDATED", http.StatusOK, user)
+}
+
+// PatchUser handles PATCH /api/v1/users/{id}
+// @Summary Partially update a user
+// @Description Apply a JSON Merge Patch; only email and pass can be changed
+// @Tags users
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param patch body PatchUserRequest true "Merge patch"
+// @Success 200 {object} domain.User
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 412 {object} utils.Response
+// @Failure 415 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /users/{id} [patch]
+func (h *UserHandler) PatchUser(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	requestID := httpMiddleware.GetRequestID(r.Context())
+	userID := strings.TrimSpace(mux.Vars(r)["id"])
+
+	logger := logger.FromContext(r.Context()).With(zap.String("user_id", userID))
+	logger.Info("PatchUser started",
+		zap.String("request_id", requestID),
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+	)
+
+	// Se acepta merge-patch+json y, por compatibilidad, application/json
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != MergePatchContentType && mediaType != "application/json" {
+		err := fmt.Errorf("unsupported patch media type %q", mediaType)
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnsupportedMedia, err))
+		return
+	}
+
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		_ = utils.WriteError(w, r, utils.BodyError(err))
+		return
+	}
+	if patch == nil {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeInvalidRequestBody, errors.New("merge patch must be a JSON object")))
+		return
+	}
+
+	changes, err := userChangesFromPatch(patch)
+	if err != nil {
+		_ = utils.WriteError(w, r, err)
+		return
+	}
+	changes.Version = ifMatchVersion(r)
+
+	dbStart := time.Now()
+	user, err := h.userService.UpdateUser(r.Context(), userID, changes)
+	dbDuration := time.Since(dbStart)
+
+	if err != nil {
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	logger.Info("PatchUser completed",
+		zap.String("request_id", requestID),
+		zap.Int("fields", len(patch)),
+		zap.Duration("db_duration", dbDuration),
+		zap.Duration("total_duration", time.Since(start)),
+	)
+
+	w.Header().Set("ETag", httpMiddleware.FormatETag(user.Version(), false))
+	_ = utils.SendSuccess(w, "SUCCESS", "USER_UPDATED", http.StatusOK, user)
+}
+
+// userChangesFromPatch convierte un merge patch en cambios del usuario. Los
+// campos fuera de la lista blanca se rechazan, igual que null: email y pass
+// no se pueden eliminar.
+func userChangesFromPatch(patch map[string]json.RawMessage) (application.UserChanges, error) {
+	var changes application.UserChanges
+	for field, raw := range patch {
+		if !patchableUserFields[field] {
+			err := fmt.Errorf("%w: field %q cannot be patched", domain.ErrInvalidUser, field)
+			return changes, apperrors.New(apperrors.CodeUserInvalid, err).WithMessage("USER_FIELD_NOT_PATCHABLE")
+		}
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil || value == nil {
+			err := fmt.Errorf("%w: field %q must be a string", domain.ErrInvalidUser, field)
+			return changes, apperrors.New(apperrors.CodeUserInvalid, err)
+		}
+		switch field {
+		case "email":
+			changes.Email = value
+		case "pass":
+			changes.Password = value
+		}
+	}
+	return changes, nil
+}
+
+// ifMatchVersion devuelve la versión del usuario de un If-Match con una sola
+// etiqueta fuerte. Con "*", varias etiquetas o sin cabecera devuelve vacío:
+// la actualización solo se condiciona a la versión que lee el servicio y la
+// eliminación no se condiciona.
+func ifMatchVersion(r *http.Request) string {
+	tag := strings.TrimSpace(r.Header.Get("If-Match"))
+	if len(tag) < 2 || strings.Contains(tag, ",") || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return ""
+	}
+	return tag[1 : len(tag)-1]
+}
+
+// DeleteUser handles DELETE /api/v1/users/{id}
+// @Summary Delete a user
+// @Description Delete a user by their ID
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 412 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /users/{id} [delete]
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	requestID := httpMiddleware.GetRequestID(r.Context())
+	userID := strings.TrimSpace(mux.Vars(r)["id"])
+
+	logger := logger.FromContext(r.Context()).With(zap.String("user_id", userID))
+	logger.Info("DeleteUser started",
+		zap.String("request_id", requestID),
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+	)
+
+	dbStart := time.Now()
+	err := h.userService.DeleteUser(r.Context(), userID, ifMatchVersion(r))
+	dbDuration := time.Since(dbStart)
+
+	if err != nil {
+		_ = utils.WriteError(w, r, userErrors.Map(err))
+		return
+	}
+
+	logger.Info("DeleteUser completed",
+		zap.String("request_id", requestID),
+		zap.Duration("db_duration", dbDuration),
+		zap.Duration("total_duration", time.Since(start)),
+	)
+
+	_ = utils.SendSuccess(w, "SUCCESS", "USER_DELETED", http.StatusOK, nil)
 }
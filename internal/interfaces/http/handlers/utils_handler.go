@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/health"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
 	"net/http"
 )
@@ -13,18 +15,56 @@ type info struct {
 	Uuid        string `json:"uuid"`
 }
 
-// Health handles the health check endpoint
+// HealthReport is the detailed health response
+type HealthReport struct {
+	info
+	Status string          `json:"status"`
+	Checks []health.Result `json:"checks"`
+}
+
+// Health handles the detailed health check endpoint, reporting the status,
+// latency and last error of every registered check
 func Health(w http.ResponseWriter, r *http.Request, app *models.Application) {
-	appInformation := info{
-		AppName:     app.Configs().AppName,
-		Version:     app.Configs().Version,
-		Environment: app.Configs().Environment,
-		Uuid:        app.Configs().Uuid,
+	ready, results := app.Health().Ready(r.Context())
+	report := HealthReport{
+		info: info{
+			AppName:     app.Configs().AppName,
+			Version:     app.Configs().Version,
+			Environment: app.Configs().Environment,
+			Uuid:        app.Configs().Uuid,
+		},
+		Status: health.StatusUp,
+		Checks: results,
+	}
+
+	if !ready {
+		report.Status = health.StatusDown
+		utils.SendSuccess(w, "UNHEALTHY", "SERVICE_UNHEALTHY", http.StatusServiceUnavailable, report)
+		return
+	}
+	utils.SendSuccess(w, "SUCCESS", "SERVICE_HEALTHY", http.StatusOK, report)
+}
+
+// Liveness handles the liveness probe. It does not check dependencies.
+func Liveness(w http.ResponseWriter, r *http.Request, app *models.Application) {
+	if !app.Health().Live() {
+		_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeServiceUnavailable, nil).WithMessage("SERVICE_NOT_ALIVE"))
+		return
+	}
+	utils.SendSuccess(w, "SUCCESS", "SERVICE_ALIVE", http.StatusOK, nil)
+}
+
+// Readiness handles the readiness probe, failing when a critical check is down
+func Readiness(w http.ResponseWriter, r *http.Request, app *models.Application) {
+	ready, results := app.Health().Ready(r.Context())
+	if !ready {
+		utils.SendSuccess(w, "NOT_READY", "SERVICE_NOT_READY", http.StatusServiceUnavailable, results)
+		return
 	}
-	utils.SendSuccess(w, "SUCCESS", "Service is healthy", http.StatusOK, appInformation)
+	utils.SendSuccess(w, "SUCCESS", "SERVICE_READY", http.StatusOK, nil)
 }
 
 // Health handles the health check endpoint
 func Rysnc(w http.ResponseWriter, r *http.Request) {
-	utils.SendSuccess(w, "SUCCESS", "Rsync started", http.StatusOK, nil)
+	utils.SendSuccess(w, "SUCCESS", "RSYNC_STARTED", http.StatusOK, nil)
 }
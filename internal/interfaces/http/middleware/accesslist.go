@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/accesslist"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// AccessList rejects with 403 the clients that list does not admit. The
+// resolved client IP is used, so it must run after ClientAddressMiddleware.
+// Blocks are logged and counted by list, route and reason.
+func AccessList(list *accesslist.List) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, _ := netip.ParseAddr(ClientIP(r))
+			ok, reason := list.Check(ip)
+			if ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route := routeTemplate(r)
+			metrics.AccessListBlocked(list.Name(), route, reason)
+			err := fmt.Errorf("client %s rejected by access list %s: %s", ClientIP(r), list.Name(), reason)
+			logger.FromContext(r.Context()).Warn("Request blocked by access list",
+				zap.String("access_list", list.Name()),
+				zap.String("reason", reason),
+				zap.String("route", route),
+			)
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeForbidden, err))
+		})
+	}
+}
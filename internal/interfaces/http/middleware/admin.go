@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// AdminAuthMiddleware protects administrative routes with a static bearer
+// token. When token is empty every request is rejected.
+func AdminAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeForbidden, errors.New("admin token is not configured")).WithMessage("ADMIN_API_DISABLED"))
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnauthorized, errors.New("invalid or missing admin token")).WithMessage("ADMIN_TOKEN_INVALID"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/auth"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// JWTAuth verifies the bearer token of the request and stores its claims in
+// the context. When optional is true, requests without a token pass through
+// anonymously, but a token that is present must still be valid.
+func JWTAuth(verifier *auth.Verifier, optional bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := auth.BearerToken(r.Header.Get("Authorization"))
+			if err != nil {
+				if optional && r.Header.Get("Authorization") == "" {
+					next.ServeHTTP(w, r)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnauthorized, err))
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				code := apperrors.CodeInvalidToken
+				if errors.Is(err, auth.ErrExpiredToken) {
+					code = apperrors.CodeTokenExpired
+				}
+				_ = utils.WriteError(w, r, apperrors.New(code, err))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// RequirePermission rejects requests whose token does not grant permission.
+// It must run after JWTAuth.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := auth.FromContext(r.Context())
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer`)
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnauthorized, auth.ErrMissingToken))
+				return
+			}
+			if !claims.HasPermission(permission) {
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+permission+`"`)
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeForbidden,
+					errors.New("subject "+claims.Subject+" lacks permission "+permission)))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
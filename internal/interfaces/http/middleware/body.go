@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// MaxBody limits the request body to limit bytes. Requests that declare a
+// larger Content-Length are rejected with 413 up front; bodies streamed
+// without a length fail when read past the limit (see http.MaxBytesError).
+func MaxBody(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodePayloadTooLarge,
+					fmt.Errorf("content length %d exceeds %d bytes", r.ContentLength, limit)))
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
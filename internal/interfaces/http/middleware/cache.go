@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/auth"
+)
+
+// CacheControl lets clients and shared caches reuse successful GET and HEAD
+// responses for ttl. Responses to authenticated requests are marked private.
+// A Cache-Control header set by the handler is kept.
+func CacheControl(ttl time.Duration) func(http.Handler) http.Handler {
+	maxAge := strconv.Itoa(int(ttl.Seconds()))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			visibility := "public"
+			if _, ok := auth.FromContext(r.Context()); ok || r.Header.Get("Authorization") != "" {
+				visibility = "private"
+			}
+			next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, value: visibility + ", max-age=" + maxAge}, r)
+		})
+	}
+}
+
+// cacheControlWriter sets Cache-Control right before a 200 response is sent
+type cacheControlWriter struct {
+	http.ResponseWriter
+	value       string
+	wroteHeader bool
+}
+
+func (cw *cacheControlWriter) WriteHeader(code int) {
+	if !cw.wroteHeader {
+		cw.wroteHeader = true
+		if code == http.StatusOK && cw.Header().Get("Cache-Control") == "" {
+			cw.Header().Set("Cache-Control", cw.value)
+		}
+	}
+	cw.ResponseWriter.WriteHeader(code)
+}
+
+func (cw *cacheControlWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *cacheControlWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ClientCertKey is the key used to store the verified client certificate identity in the context
+const ClientCertKey contextKey = "clientCert"
+
+// ClientIdentity describes the certificate presented by the peer over mutual TLS
+type ClientIdentity struct {
+	Subject      string   `json:"subject"`
+	CommonName   string   `json:"commonName"`
+	Issuer       string   `json:"issuer"`
+	SerialNumber string   `json:"serialNumber"`
+	DNSNames     []string `json:"dnsNames,omitempty"`
+	Fingerprint  string   `json:"fingerprint"` // SHA-256 of the DER certificate
+	Verified     bool     `json:"verified"`    // Whether the chain was verified against the client CA bundle
+}
+
+// GetClientIdentity retrieves the client certificate identity from the context
+func GetClientIdentity(ctx context.Context) (*ClientIdentity, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	id, ok := ctx.Value(ClientCertKey).(*ClientIdentity)
+	return id, ok
+}
+
+// ClientCertMiddleware exposes the peer certificate of mutual TLS connections
+// to handlers through GetClientIdentity and the request-scoped logger
+func ClientCertMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cert := r.TLS.PeerCertificates[0]
+		sum := sha256.Sum256(cert.Raw)
+		id := &ClientIdentity{
+			Subject:      cert.Subject.String(),
+			CommonName:   cert.Subject.CommonName,
+			Issuer:       cert.Issuer.String(),
+			SerialNumber: cert.SerialNumber.String(),
+			DNSNames:     cert.DNSNames,
+			Fingerprint:  hex.EncodeToString(sum[:]),
+			Verified:     len(r.TLS.VerifiedChains) > 0,
+		}
+
+		ctx := context.WithValue(r.Context(), ClientCertKey, id)
+		ctx = logger.NewContext(ctx, logger.FromContext(ctx).With(zap.String("client_subject", id.Subject)))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -2,9 +2,13 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/tracing"
+
 	"github.com/google/uuid"
 )
 
@@ -15,6 +19,18 @@ const (
 	RequestIDKey contextKey = "requestID"
 )
 
+// Límites de paginación
+const (
+	DefaultPageLimit int64 = 10
+	MaxPageLimit     int64 = 100
+)
+
+// Errores de los parámetros de paginación
+var (
+	ErrInvalidPageParam  = errors.New("invalid page parameter")
+	ErrInvalidLimitParam = errors.New("invalid limit parameter")
+)
+
 // GetRequestID retrieves the request ID from the context
 func GetRequestID(ctx context.Context) string {
 	if ctx == nil {
@@ -29,15 +45,19 @@ func GetRequestID(ctx context.Context) string {
 // RequestIDMiddleware adds a request ID to the context and response headers
 func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Generate or get request ID from header
+		// Generate or get request ID from header, defaulting to the trace ID
+		// so logs, responses and traces share the same identifier
 		requestID := r.Header.Get("X-Request-ID")
 		if requestID == "" {
-			// In a real app, you might want to generate a UUID here
+			requestID = tracing.TraceID(r.Context())
+		}
+		if requestID == "" {
 			requestID = uuid.NewString()
 		}
 
-		// Add request ID to context
+		// Add request ID to context and to the request-scoped logger
 		ctx := context.WithValue(r.Context(), RequestIDKey, requestID)
+		ctx = logger.NewContext(ctx, logger.WithRequestID(logger.FromContext(ctx), requestID))
 
 		// Add request ID to response headers
 		w.Header().Set("X-Request-ID", requestID)
@@ -46,15 +66,16 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// GetPaginationParams extracts and validates pagination parameters from the request
+// GetPaginationParams extracts and validates pagination parameters from the
+// request. Limits above MaxPageLimit are capped to it.
 func GetPaginationParams(r *http.Request) (int64, int64, error) {
 	page := int64(1)
-	limit := int64(10)
+	limit := DefaultPageLimit
 
 	// Parse page parameter
 	if p := r.URL.Query().Get("page"); p != "" {
 		if _, err := fmt.Sscanf(p, "%d", &page); err != nil {
-			return 0, 0, fmt.Errorf("invalid page parameter")
+			return 0, 0, ErrInvalidPageParam
 		}
 		if page < 1 {
 			page = 1
@@ -64,13 +85,13 @@ func GetPaginationParams(r *http.Request) (int64, int64, error) {
 	// Parse limit parameter
 	if l := r.URL.Query().Get("limit"); l != "" {
 		if _, err := fmt.Sscanf(l, "%d", &limit); err != nil {
-			return 0, 0, fmt.Errorf("invalid limit parameter")
+			return 0, 0, ErrInvalidLimitParam
 		}
 		if limit < 1 {
-			limit = 10
+			limit = DefaultPageLimit
 		}
-		if limit > 100 {
-			limit = 10000000
+		if limit > MaxPageLimit {
+			limit = MaxPageLimit
 		}
 	}
 
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// ETagOptions configures conditional request handling for a route
+type ETagOptions struct {
+	// Weak emits weak validators (W/"...") instead of strong ones
+	Weak bool
+	// Current returns the current representation of the resource and is used to
+	// evaluate If-Match on PUT, PATCH and DELETE. It is normally the GET handler
+	// of the same route; it is invoked with a copy of the request using GET.
+	Current http.Handler
+}
+
+// ETag returns a middleware that adds an ETag to successful responses and
+// evaluates If-None-Match (304) and If-Match (412) preconditions.
+//
+// The validator is taken from the ETag header when the handler sets one (for
+// example from a document version, see FormatETag). Otherwise it is computed
+// from the "data" field of the utils.Response envelope, so the per-response
+// datetime does not change it.
+func ETag(opts ETagOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead:
+				serveConditionalRead(w, r, next, opts)
+			case http.MethodPut, http.MethodPatch, http.MethodDelete:
+				if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && opts.Current != nil {
+					if !currentMatches(r, ifMatch, opts) {
+						_ = utils.WriteError(w, r, apperrors.New(apperrors.CodePreconditionFailed, errors.New("If-Match does not match the current representation")))
+						return
+					}
+				}
+				next.ServeHTTP(w, r)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// FormatETag formats an opaque value (for example a document version) as an
+// entity tag that handlers can set on the ETag header
+func FormatETag(value string, weak bool) string {
+	tag := `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	if weak {
+		return "W/" + tag
+	}
+	return tag
+}
+
+func serveConditionalRead(w http.ResponseWriter, r *http.Request, next http.Handler, opts ETagOptions) {
+	rec := newResponseRecorder(w.Header())
+	next.ServeHTTP(rec, r)
+
+	if rec.statusCode != http.StatusOK {
+		rec.flush(w)
+		return
+	}
+
+	etag := responseETag(rec, opts.Weak)
+	if etag == "" {
+		rec.flush(w)
+		return
+	}
+	rec.header.Set("ETag", etag)
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" && matchesAny(inm, etag, false) {
+		dst := w.Header()
+		for _, h := range []string{"ETag", "Cache-Control", "Vary", "X-Request-ID"} {
+			if v := rec.header.Get(h); v != "" {
+				dst.Set(h, v)
+			}
+		}
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	rec.flush(w)
+}
+
+// currentMatches resolves the current representation through opts.Current and
+// compares it against the If-Match header using strong comparison
+func currentMatches(r *http.Request, ifMatch string, opts ETagOptions) bool {
+	probe := r.Clone(r.Context())
+	probe.Method = http.MethodGet
+	probe.Body = http.NoBody
+	probe.ContentLength = 0
+	probe.Header.Del("If-None-Match")
+
+	rec := newResponseRecorder(nil)
+	opts.Current.ServeHTTP(rec, probe)
+	if rec.statusCode != http.StatusOK {
+		return false
+	}
+	if strings.TrimSpace(ifMatch) == "*" {
+		return true
+	}
+	return matchesAny(ifMatch, responseETag(rec, opts.Weak), true)
+}
+
+// responseETag returns the ETag set by the handler or computes one from the body
+func responseETag(rec *responseRecorder, weak bool) string {
+	if etag := rec.header.Get("ETag"); etag != "" {
+		return etag
+	}
+	if rec.body.Len() == 0 {
+		return ""
+	}
+
+	payload := rec.body.Bytes()
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err == nil && len(envelope.Data) > 0 {
+		payload = envelope.Data
+	}
+
+	sum := sha256.Sum256(payload)
+	return FormatETag(hex.EncodeToString(sum[:16]), weak)
+}
+
+// matchesAny reports whether etag matches any entity tag in an If-Match or
+// If-None-Match header value. Strong comparison never matches weak tags.
+func matchesAny(header, etag string, strong bool) bool {
+	if etag == "" {
+		return false
+	}
+	if strong && strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strong {
+			if candidate == etag {
+				return true
+			}
+			continue
+		}
+		if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
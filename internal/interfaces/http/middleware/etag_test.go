@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETagMiddleware(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	get := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = utils.SendSuccess(w, "SUCCESS", "ok", http.StatusOK, map[string]string{"id": "1"})
+	})
+	put := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	opts := ETagOptions{Current: get}
+
+	rr := httptest.NewRecorder()
+	ETag(opts)(get).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	etag := rr.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.True(t, strings.HasPrefix(etag, `"`), "strong ETag expected, got %q", etag)
+
+	t.Run("IfNoneMatchReturns304", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+		req.Header.Set("If-None-Match", "W/"+etag)
+		rr := httptest.NewRecorder()
+		ETag(opts)(get).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Empty(t, rr.Body.String())
+		assert.Equal(t, etag, rr.Header().Get("ETag"))
+	})
+
+	t.Run("IfMatchMismatchReturns412", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/1", nil)
+		req.Header.Set("If-Match", `"stale"`)
+		rr := httptest.NewRecorder()
+		ETag(opts)(put).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, rr.Code)
+	})
+
+	t.Run("IfMatchCurrentPassesThrough", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/users/1", nil)
+		req.Header.Set("If-Match", etag)
+		rr := httptest.NewRecorder()
+		ETag(opts)(put).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
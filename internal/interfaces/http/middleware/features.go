@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// Headers used to evaluate feature flags per request
+const (
+	UserIDHeader       = "X-User-ID"
+	TenantIDHeader     = "X-Tenant-ID"
+	FeatureFlagsHeader = "X-Feature-Flags"
+)
+
+// FeatureContextMiddleware stores the user, tenant and header overrides used
+// to evaluate feature flags in the request context
+func FeatureContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ec := features.EvalContext{
+			UserID:    r.Header.Get(UserIDHeader),
+			TenantID:  r.Header.Get(TenantIDHeader),
+			Overrides: features.ParseOverrides(r.Header.Get(FeatureFlagsHeader)),
+		}
+		next.ServeHTTP(w, r.WithContext(features.WithEvalContext(r.Context(), ec)))
+	})
+}
+
+// MaintenanceMiddleware answers 503 with Retry-After while the maintenance_mode
+// flag is on. Paths under any of the exempt prefixes are still served; a
+// prefix only matches whole segments, so /health does not exempt /healthz.
+func MaintenanceMiddleware(store *features.Store, retryAfterSeconds int, exempt ...string) func(http.Handler) http.Handler {
+	retryAfter := strconv.Itoa(retryAfterSeconds)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !store.IsEnabled(r.Context(), features.MaintenanceMode) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			for _, prefix := range exempt {
+				if p := r.URL.Path; p == prefix || strings.HasPrefix(p, strings.TrimSuffix(prefix, "/")+"/") {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			w.Header().Set("Retry-After", retryAfter)
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeServiceUnavailable, nil).WithMessage("MAINTENANCE_MODE"))
+		})
+	}
+}
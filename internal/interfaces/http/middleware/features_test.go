@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	store := features.NewStore(config.FeaturesConfig{MaintenanceMode: true}, nil)
+	h := MaintenanceMiddleware(store, 30, "/api/v1/health", "/metrics")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"/api/v1/health", http.StatusOK},
+		{"/api/v1/health/live", http.StatusOK},
+		{"/metrics", http.StatusOK},
+		{"/api/v1/healthcheck-admin", http.StatusServiceUnavailable},
+		{"/api/v1/health-export", http.StatusServiceUnavailable},
+		{"/metricsfoo", http.StatusServiceUnavailable},
+		{"/api/v1/users", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.want, rr.Code)
+			if tt.want == http.StatusServiceUnavailable {
+				assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	t.Run("ServedWhenOff", func(t *testing.T) {
+		store.Set(features.Flag{Name: features.MaintenanceMode, Enabled: false})
+		defer store.Reset(features.MaintenanceMode)
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses served from the idempotency store
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyStore persists idempotency keys with their request fingerprint
+// and the response produced for them
+type IdempotencyStore interface {
+	// Reserve stores an in-flight record for key. When the key already exists
+	// it returns the stored record and false instead.
+	Reserve(ctx context.Context, key, fingerprint string) (*domain.IdempotencyRecord, bool, error)
+	// Complete stores the response produced for key
+	Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error
+	// Release removes an in-flight key so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// Idempotency returns a middleware that makes requests carrying an
+// Idempotency-Key header safe to retry:
+//   - the first request runs and its response is stored
+//   - repeats with the same payload receive the stored response
+//   - a duplicate arriving while the first is in flight receives 409
+//   - reusing the key with a different payload receives 422
+//
+// Requests without the header are passed through untouched. Server errors
+// (5xx) are not stored, so the client can retry with the same key. The body
+// is buffered to fingerprint the request, so bodies above maxBody bytes are
+// rejected with 413.
+func Idempotency(store IdempotencyStore, maxBody int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := logger.FromContext(r.Context()).With(
+				zap.String("request_id", GetRequestID(r.Context())),
+				zap.String("idempotency_key", key),
+			)
+
+			if len(key) > maxIdempotencyKeyLength {
+				err := fmt.Errorf("idempotency key of %d characters", len(key))
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeBadRequest, err).WithMessage("IDEMPOTENCY_KEY_TOO_LONG"))
+				return
+			}
+
+			if r.ContentLength > maxBody {
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodePayloadTooLarge,
+					fmt.Errorf("content length %d exceeds %d bytes", r.ContentLength, maxBody)))
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+			if err != nil {
+				_ = utils.WriteError(w, r, utils.BodyError(err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(r, body)
+			record, reserved, err := store.Reserve(r.Context(), key, fingerprint)
+			if err != nil {
+				_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeInternal, fmt.Errorf("reserve idempotency key: %w", err)).WithMessage("IDEMPOTENCY_FAILED"))
+				return
+			}
+
+			if !reserved {
+				switch {
+				case record.Fingerprint != fingerprint:
+					err := errors.New("idempotency key reused with a different payload")
+					_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnprocessableEntity, err).WithMessage("IDEMPOTENCY_KEY_MISMATCH"))
+				case !record.Completed:
+					err := errors.New("idempotency key is already in flight")
+					_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeConflict, err).WithMessage("IDEMPOTENCY_KEY_IN_PROGRESS"))
+				default:
+					log.Info("Replaying stored response for idempotency key")
+					replayResponse(w, record)
+				}
+				return
+			}
+
+			base := w.Header().Clone()
+			rec := newResponseRecorder(base)
+			next.ServeHTTP(rec, r)
+
+			// Persist with a context that outlives a client disconnect
+			storeCtx := context.WithoutCancel(r.Context())
+			if rec.statusCode >= http.StatusInternalServerError {
+				if err := store.Release(storeCtx, key); err != nil {
+					log.Error("Failed to release idempotency key", zap.Error(err))
+				}
+			} else if err := store.Complete(storeCtx, key, rec.statusCode, rec.headerSince(base), rec.body.Bytes()); err != nil {
+				log.Error("Failed to store idempotent response", zap.Error(err))
+			}
+
+			rec.flush(w)
+		})
+	}
+}
+
+// requestFingerprint identifies the request a key was first used with
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayResponse(w http.ResponseWriter, record *domain.IdempotencyRecord) {
+	dst := w.Header()
+	for k, v := range record.Header {
+		dst[k] = v
+	}
+	dst.Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
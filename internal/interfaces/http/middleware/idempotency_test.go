@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore guarda las claves en memoria con la semántica del store de Mongo
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (s *memoryStore) Reserve(ctx context.Context, key, fingerprint string) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[key]; ok {
+		stored := *record
+		return &stored, false, nil
+	}
+	s.records[key] = &domain.IdempotencyRecord{Key: key, Fingerprint: fingerprint}
+	return nil, true, nil
+}
+
+func (s *memoryStore) Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.records[key]
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.Header = header
+	record.Body = append([]byte(nil), body...)
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *memoryStore) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.records[key]
+	return ok
+}
+
+func idempotentRequest(key, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	t.Run("ReplaysStoredResponse", func(t *testing.T) {
+		calls := 0
+		h := Idempotency(newMemoryStore(), 1<<20)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Set("Location", "/users/1")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}))
+
+		first := httptest.NewRecorder()
+		h.ServeHTTP(first, idempotentRequest("k1", `{"email":"a@b.co"}`))
+		second := httptest.NewRecorder()
+		h.ServeHTTP(second, idempotentRequest("k1", `{"email":"a@b.co"}`))
+
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, first.Code, second.Code)
+		assert.Equal(t, "/users/1", second.Header().Get("Location"))
+		assert.Equal(t, first.Body.String(), second.Body.String())
+	})
+
+	t.Run("InFlightKeyGets409", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		h := Idempotency(newMemoryStore(), 1<<20)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		done := make(chan int)
+		go func() {
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, idempotentRequest("k1", `{}`))
+			done <- rr.Code
+		}()
+		<-started
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, idempotentRequest("k1", `{}`))
+		close(release)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "CONFLICT")
+		assert.Equal(t, http.StatusCreated, <-done)
+	})
+
+	t.Run("DifferentPayloadGets422", func(t *testing.T) {
+		h := Idempotency(newMemoryStore(), 1<<20)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, idempotentRequest("k1", `{"email":"a@b.co"}`))
+		require.Equal(t, http.StatusCreated, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, idempotentRequest("k1", `{"email":"c@d.co"}`))
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("ServerErrorsAreNotStored", func(t *testing.T) {
+		store := newMemoryStore()
+		calls := 0
+		h := Idempotency(store, 1<<20)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, idempotentRequest("k1", `{}`))
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		assert.False(t, store.has("k1"))
+
+		rr = httptest.NewRecorder()
+		h.ServeHTTP(rr, idempotentRequest("k1", `{}`))
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestIdempotencyLimitsBufferedBody(t *testing.T) {
+	_ = logger.InitLogger(false)
+	store := newMemoryStore()
+	h := Idempotency(store, 8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// Sin Content-Length el límite se aplica al leer
+	req := idempotentRequest("k1", `{"email":"a@b.co"}`)
+	req.ContentLength = -1
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	assert.Contains(t, rr.Body.String(), "PAYLOAD_TOO_LARGE")
+	assert.False(t, store.has("k1"))
+
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, idempotentRequest("k2", `{}`))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/i18n"
+)
+
+// LanguageMiddleware negotiates the response language from Accept-Language.
+// The language is stored in the context and sent as Content-Language, which
+// is where utils.SendSuccess and utils.SendError read it from.
+func LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.Match(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", lang)
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), lang)))
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// MetricsMiddleware records request count, latency and in-flight requests
+// labelled by the matched route template rather than the raw path
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		done := metrics.HTTPRequestStarted(routeTemplate(r), r.Method)
+		lrw := newLoggingResponseWriter(w)
+		next.ServeHTTP(lrw, r)
+		done(lrw.statusCode)
+	})
+}
+
+// routeTemplate returns the path template of the matched mux route
+func routeTemplate(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if tpl, err := current.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unmatched"
+}
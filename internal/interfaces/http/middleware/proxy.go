@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ClientAddressKey is the key used to store the resolved client address in the context
+const ClientAddressKey contextKey = "clientAddress"
+
+// ClientAddress is the original client of a request as seen by the first
+// trusted hop: its IP, and the scheme and host it used
+type ClientAddress struct {
+	IP     netip.Addr
+	Scheme string
+	Host   string
+}
+
+// GetClientAddress retrieves the resolved client address from the context
+func GetClientAddress(ctx context.Context) (ClientAddress, bool) {
+	if ctx == nil {
+		return ClientAddress{}, false
+	}
+	addr, ok := ctx.Value(ClientAddressKey).(ClientAddress)
+	return addr, ok
+}
+
+// ClientIP returns the resolved client IP of r, or the host of RemoteAddr
+// when ClientAddressMiddleware did not run
+func ClientIP(r *http.Request) string {
+	if addr, ok := GetClientAddress(r.Context()); ok && addr.IP.IsValid() {
+		return addr.IP.String()
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ClientAddressMiddleware resolves the client IP, scheme and host of each
+// request. Forwarded, X-Forwarded-For (with X-Forwarded-Proto and
+// X-Forwarded-Host) and X-Real-IP are only read when the peer is a trusted
+// proxy, and hops are walked from the nearest one until the first address
+// outside the trusted ranges, so clients cannot spoof their address.
+func ClientAddressMiddleware(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addr := resolveClientAddress(r, trusted)
+			ctx := context.WithValue(r.Context(), ClientAddressKey, addr)
+			ctx = logger.NewContext(ctx, logger.FromContext(ctx).With(zap.String("client_ip", addr.IP.String())))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// forwardedHop is the information a proxy added about the request it received
+type forwardedHop struct {
+	ip     netip.Addr // invalid for unknown or obfuscated nodes
+	scheme string
+	host   string
+}
+
+func resolveClientAddress(r *http.Request, trusted []netip.Prefix) ClientAddress {
+	addr := ClientAddress{IP: parseNode(r.RemoteAddr), Scheme: "http", Host: r.Host}
+	if r.TLS != nil {
+		addr.Scheme = "https"
+	}
+	if !isTrusted(addr.IP, trusted) {
+		return addr
+	}
+
+	// Cada salto lo agregó un proxy de confianza mientras el anterior lo sea
+	hops := forwardedHops(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := hops[i]
+		if hop.scheme != "" {
+			addr.Scheme = hop.scheme
+		}
+		if hop.host != "" {
+			addr.Host = hop.host
+		}
+		if !hop.ip.IsValid() {
+			break
+		}
+		addr.IP = hop.ip
+		if !isTrusted(hop.ip, trusted) {
+			break
+		}
+	}
+	return addr
+}
+
+// forwardedHops reads the hops of the Forwarded header or, when absent, of
+// the X-Forwarded-* headers or X-Real-IP. The nearest hop is last.
+func forwardedHops(h http.Header) []forwardedHop {
+	var hops []forwardedHop
+	if values := h.Values("Forwarded"); len(values) > 0 {
+		for _, element := range splitList(values) {
+			var hop forwardedHop
+			for _, pair := range strings.Split(element, ";") {
+				key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+				value = strings.Trim(value, `"`)
+				switch strings.ToLower(key) {
+				case "for":
+					hop.ip = parseNode(value)
+				case "proto":
+					hop.scheme = parseScheme(value)
+				case "host":
+					hop.host = parseHost(value)
+				}
+			}
+			hops = append(hops, hop)
+		}
+		return hops
+	}
+
+	nodes := splitList(h.Values("X-Forwarded-For"))
+	if len(nodes) == 0 {
+		if realIP := strings.TrimSpace(h.Get("X-Real-IP")); realIP != "" {
+			nodes = []string{realIP}
+		}
+	}
+	for _, node := range nodes {
+		hops = append(hops, forwardedHop{ip: parseNode(node)})
+	}
+	// Proto y host se alinean desde el salto más cercano
+	schemes := splitList(h.Values("X-Forwarded-Proto"))
+	hosts := splitList(h.Values("X-Forwarded-Host"))
+	for j := 0; j < len(hops); j++ {
+		hop := &hops[len(hops)-1-j]
+		if j < len(schemes) {
+			hop.scheme = parseScheme(schemes[len(schemes)-1-j])
+		}
+		if j < len(hosts) {
+			hop.host = parseHost(hosts[len(hosts)-1-j])
+		}
+	}
+	return hops
+}
+
+// splitList splits comma-separated header values into trimmed items
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// parseNode parses an address with an optional port, such as 192.0.2.1,
+// 192.0.2.1:443 or [2001:db8::1]:443
+func parseNode(node string) netip.Addr {
+	node = strings.TrimSpace(node)
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		node = host
+	}
+	ip, err := netip.ParseAddr(strings.Trim(node, "[]"))
+	if err != nil {
+		return netip.Addr{}
+	}
+	return ip.Unmap()
+}
+
+func parseScheme(value string) string {
+	switch scheme := strings.ToLower(strings.TrimSpace(value)); scheme {
+	case "http", "https":
+		return scheme
+	}
+	return ""
+}
+
+func parseHost(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.ContainsAny(value, "/ \\@") {
+		return ""
+	}
+	return value
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveClientAddress(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		ip      string
+		scheme  string
+		host    string
+	}{
+		{
+			name:    "UntrustedPeerIgnoresHeaders",
+			remote:  "203.0.113.7:5000",
+			headers: map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Forwarded-Proto": "https"},
+			ip:      "203.0.113.7", scheme: "http", host: "api.local",
+		},
+		{
+			name:    "XForwardedForStopsAtFirstUntrustedHop",
+			remote:  "10.0.0.2:5000",
+			headers: map[string]string{"X-Forwarded-For": "1.1.1.1, 198.51.100.1, 10.0.0.1", "X-Forwarded-Proto": "https", "X-Forwarded-Host": "api.example.com"},
+			ip:      "198.51.100.1", scheme: "https", host: "api.example.com",
+		},
+		{
+			name:    "Forwarded",
+			remote:  "10.0.0.2:5000",
+			headers: map[string]string{"Forwarded": `for=1.1.1.1, for="[2001:db8::1]:4711";proto=https;host=api.example.com`},
+			ip:      "2001:db8::1", scheme: "https", host: "api.example.com",
+		},
+		{
+			name:    "RealIP",
+			remote:  "10.0.0.2:5000",
+			headers: map[string]string{"X-Real-IP": "198.51.100.9"},
+			ip:      "198.51.100.9", scheme: "http", host: "api.local",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://api.local/users", nil)
+			r.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			addr := resolveClientAddress(r, trusted)
+			assert.Equal(t, tt.ip, addr.IP.String())
+			assert.Equal(t, tt.scheme, addr.Scheme)
+			assert.Equal(t, tt.host, addr.Host)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	serve := func(h http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("ByIP", func(t *testing.T) {
+		h := RateLimit(ratelimit.New(0.5, 1), RateLimitByIP)(ok)
+
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1000").Code)
+		rr := serve(h, "10.0.0.1:2000")
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "2", rr.Header().Get("Retry-After"))
+		assert.Contains(t, rr.Body.String(), "TOO_MANY_REQUESTS")
+
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.2:1000").Code)
+	})
+
+	t.Run("ByRoute", func(t *testing.T) {
+		h := RateLimit(ratelimit.New(1, 1), RateLimitByRoute)(ok)
+
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1000").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve(h, "10.0.0.2:1000").Code)
+	})
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+)
+
+// responseRecorder buffers a handler's response so a middleware can inspect
+// or replace it before anything reaches the client
+type responseRecorder struct {
+	header      http.Header
+	body        bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+// newResponseRecorder starts from a copy of base, usually the headers already
+// set on the real writer, so the handler sees headers set by earlier
+// middleware such as Content-Language
+func newResponseRecorder(base http.Header) *responseRecorder {
+	header := base.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &responseRecorder{header: header, statusCode: http.StatusOK}
+}
+
+func (rr *responseRecorder) Header() http.Header {
+	return rr.header
+}
+
+func (rr *responseRecorder) WriteHeader(code int) {
+	if rr.wroteHeader {
+		return
+	}
+	rr.statusCode = code
+	rr.wroteHeader = true
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if !rr.wroteHeader {
+		rr.WriteHeader(http.StatusOK)
+	}
+	return rr.body.Write(b)
+}
+
+// flush copies the buffered headers, status and body to w
+func (rr *responseRecorder) flush(w http.ResponseWriter) {
+	dst := w.Header()
+	for k, v := range rr.header {
+		dst[k] = v
+	}
+	w.WriteHeader(rr.statusCode)
+	_, _ = w.Write(rr.body.Bytes())
+}
+
+// headerSince returns the headers set or changed by the handler relative to
+// base, leaving out per-request headers added by earlier middleware
+func (rr *responseRecorder) headerSince(base http.Header) http.Header {
+	changed := make(http.Header)
+	for k, v := range rr.header {
+		if !slices.Equal(base[k], v) {
+			changed[k] = slices.Clone(v)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// SecurityHeadersOptions configures SecurityHeaders
+type SecurityHeadersOptions struct {
+	// Headers are set on every response before the handler runs, so routes
+	// and handlers can override them
+	Headers http.Header
+	// Strip lists server-identifying headers removed before the response is sent
+	Strip []string
+}
+
+// SecurityHeaders sets the hardening headers of opts on every response,
+// removes server-identifying headers and marks responses to authenticated
+// requests with Cache-Control: no-store unless the handler chose a policy
+func SecurityHeaders(opts SecurityHeadersOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dst := w.Header()
+			for k, v := range opts.Headers {
+				dst[k] = v
+			}
+			next.ServeHTTP(&securityHeadersWriter{
+				ResponseWriter: w,
+				strip:          opts.Strip,
+				noStore:        r.Header.Get("Authorization") != "",
+			}, r)
+		})
+	}
+}
+
+// HeaderOverrides sets response headers of a route, overriding those of
+// SecurityHeaders. An empty value removes the header.
+func HeaderOverrides(headers map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range headers {
+				if v == "" {
+					w.Header().Del(k)
+				} else {
+					w.Header().Set(k, v)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// securityHeadersWriter applies the last-moment rules of SecurityHeaders
+// right before the status line is sent
+type securityHeadersWriter struct {
+	http.ResponseWriter
+	strip       []string
+	noStore     bool
+	wroteHeader bool
+}
+
+func (sw *securityHeadersWriter) WriteHeader(code int) {
+	if !sw.wroteHeader {
+		sw.wroteHeader = true
+		h := sw.ResponseWriter.Header()
+		for _, name := range sw.strip {
+			h.Del(name)
+		}
+		if sw.noStore && h.Get("Cache-Control") == "" {
+			h.Set("Cache-Control", "no-store")
+		}
+	}
+	sw.ResponseWriter.WriteHeader(code)
+}
+
+func (sw *securityHeadersWriter) Write(b []byte) (int, error) {
+	if !sw.wroteHeader {
+		sw.WriteHeader(http.StatusOK)
+	}
+	return sw.ResponseWriter.Write(b)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (sw *securityHeadersWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	t.Run("FastHandlerIsServed", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.True(t, ok, "request context must carry the deadline")
+			w.Header().Set("X-Test", "1")
+			_ = utils.SendSuccess(w, "SUCCESS", "ok", http.StatusCreated, nil)
+		})
+		rr := httptest.NewRecorder()
+		Timeout(time.Second)(h).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get("X-Test"))
+		assert.Contains(t, rr.Body.String(), `"SUCCESS"`)
+	})
+
+	t.Run("SlowHandlerGets504Once", func(t *testing.T) {
+		writeErr := make(chan error, 1)
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+			time.Sleep(10 * time.Millisecond)
+			_, err := w.Write([]byte("late"))
+			writeErr <- err
+		})
+		rr := httptest.NewRecorder()
+		Timeout(20*time.Millisecond)(h).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+		assert.Equal(t, 1, strings.Count(rr.Body.String(), "GATEWAY_TIMEOUT"))
+		assert.ErrorIs(t, <-writeErr, http.ErrHandlerTimeout)
+		assert.NotContains(t, rr.Body.String(), "late")
+	})
+
+	t.Run("KeepsNegotiatedLanguage", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = utils.SendSuccess(w, "SUCCESS", "SERVICE_ALIVE", http.StatusOK, nil)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("Accept-Language", "es")
+		rr := httptest.NewRecorder()
+		LanguageMiddleware(Timeout(time.Second)(h)).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "es", rr.Header().Get("Content-Language"))
+		assert.Equal(t, []string{"Accept-Language"}, rr.Header().Values("Vary"))
+		assert.Contains(t, rr.Body.String(), "El servicio está vivo")
+	})
+
+	t.Run("TimeoutUsesNegotiatedLanguage", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		})
+		req := httptest.NewRequest(http.MethodGet, "/users", nil)
+		req.Header.Set("Accept-Language", "es")
+		rr := httptest.NewRecorder()
+		LanguageMiddleware(Timeout(20*time.Millisecond)(h)).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+		assert.Equal(t, "es", rr.Header().Get("Content-Language"))
+		assert.Contains(t, rr.Body.String(), "La solicitud tardó demasiado en completarse")
+	})
+}
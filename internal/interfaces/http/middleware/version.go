@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/versioning"
+
+	"go.uber.org/zap"
+)
+
+// APIVersion stores v in the request context and sets the version headers.
+// Requests to a deprecated version are counted by route so the version can
+// be retired once the counter stops growing.
+func APIVersion(v versioning.Version) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v.WriteHeaders(w.Header())
+			if v.Deprecated {
+				metrics.DeprecatedVersionRequest(v.Name, routeTemplate(r), r.Method)
+			}
+			next.ServeHTTP(w, r.WithContext(versioning.WithVersion(r.Context(), v)))
+		})
+	}
+}
+
+// VersionDispatcher serves the paths under the API root that no version
+// router matched. Unversioned paths (/api/users) are rewritten to the version
+// negotiated from Accept-Version; paths of a known version are handed to that
+// version's router so it answers 404 or 405.
+func VersionDispatcher(set *versioning.Set, routers map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rel := strings.TrimPrefix(r.URL.Path, set.Root())
+		segment, _, _ := strings.Cut(strings.TrimPrefix(rel, "/"), "/")
+		if v, ok := set.Lookup(segment); ok {
+			routers[v.Name].ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", versioning.AcceptVersionHeader)
+		v, err := set.Negotiate(r.Header.Get(versioning.AcceptVersionHeader))
+		if err != nil {
+			logger.FromContext(r.Context()).Debug("Unsupported API version", zap.Error(err))
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeUnsupportedVersion, err))
+			return
+		}
+
+		versioned := r.Clone(r.Context())
+		versioned.URL.Path = v.Path + rel
+		if r.URL.RawPath != "" {
+			versioned.URL.RawPath = v.Path + strings.TrimPrefix(r.URL.RawPath, set.Root())
+		}
+		routers[v.Name].ServeHTTP(w, versioned)
+	})
+}
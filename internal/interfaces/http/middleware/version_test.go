@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/versioning"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionDispatcher(t *testing.T) {
+	_ = logger.InitLogger(false)
+
+	set := versioning.New(config.HTTPConfig{
+		BasePath: "/api/v1",
+		Versioning: config.VersioningConfig{
+			Default:  "v1",
+			Versions: []config.VersionConfig{{Name: "v1"}, {Name: "v2"}},
+		},
+	})
+	routers := make(map[string]http.Handler)
+	for _, v := range set.All() {
+		routers[v.Name] = APIVersion(v)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.URL.Path))
+		}))
+	}
+	h := VersionDispatcher(set, routers)
+
+	tests := []struct {
+		name    string
+		path    string
+		accept  string
+		status  int
+		version string
+		wantURL string
+	}{
+		{"DefaultVersion", "/api/users", "", http.StatusOK, "v1", "/api/v1/users"},
+		{"AcceptVersion", "/api/users", "v2", http.StatusOK, "v2", "/api/v2/users"},
+		{"AcceptVersionNumber", "/api/users", "2", http.StatusOK, "v2", "/api/v2/users"},
+		{"VersionedPathIgnoresHeader", "/api/v2/unknown", "v1", http.StatusOK, "v2", "/api/v2/unknown"},
+		{"UnsupportedVersion", "/api/users", "v9", http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set(versioning.AcceptVersionHeader, tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status != http.StatusOK {
+				assert.Contains(t, rr.Body.String(), "UNSUPPORTED_API_VERSION")
+				return
+			}
+			assert.Equal(t, tt.version, rr.Header().Get(versioning.VersionHeader))
+			assert.Equal(t, tt.wantURL, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package http
+
+import (
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/auth"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/ratelimit"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/versioning"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// applyRoutePolicies wraps the routes matched by the routes section of the
+// configuration with their policy. A policy key is a route name, a template
+// ("/users/{id}") or a method and a template ("GET /users/{id}"); templates
+// may include the version path or be relative to it. Keys that match no
+// route are reported as an error so typos do not go unnoticed.
+func applyRoutePolicies(a *models.Application, r *mux.Router, versions *versioning.Set) error {
+	policies := a.Configs().Routes
+	if len(policies) == 0 {
+		return nil
+	}
+	verifier := auth.NewVerifier(a.Configs().App.JWTSecret)
+	used := make(map[string]bool)
+
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		h := route.GetHandler()
+		if h == nil {
+			return nil
+		}
+		full, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		rel := relativeTemplate(full, versions)
+
+		// Cada método puede tener su propia política
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{routetable.AnyMethod}
+		}
+		keys := make(map[string]string, len(methods))
+		for _, method := range methods {
+			if key, ok := matchPolicy(policies, route.GetName(), method, full, rel); ok {
+				keys[method] = key
+				used[key] = true
+			}
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		byMethod := make(map[string]http.Handler, len(keys))
+		var names []string
+		authMode := ""
+		for _, method := range methods {
+			key, ok := keys[method]
+			if !ok {
+				continue
+			}
+			policy := policies[key]
+			handler, applied := wrapRoutePolicy(h, policy, verifier)
+			byMethod[method] = handler
+			names = append(names, "policy:"+key)
+			names = append(names, applied...)
+			if policy.Auth != config.RouteAuthNone {
+				authMode = policy.Auth
+			}
+			documentRoutePolicy(a.OpenAPI(), method, full, policy)
+		}
+
+		if len(byMethod) == len(methods) && samePolicy(keys) {
+			route.Handler(byMethod[methods[0]])
+		} else {
+			route.Handler(methodPolicies(byMethod, h))
+		}
+		a.Routes().Annotate(route, routetable.Meta{Middleware: slices.Compact(names), Auth: authMode})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for key := range policies {
+		if !used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return fmt.Errorf("routes policies match no route: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// applyAccessLists wraps the routes of the group of each access list, the
+// routes whose template starts with one of its paths, with the list. They
+// are applied after the route policies so blocked clients are rejected
+// before authentication. Paths that match no route are reported as an error.
+func applyAccessLists(a *models.Application, r *mux.Router, versions *versioning.Set) error {
+	registry := a.AccessLists()
+	used := make(map[string]bool)
+
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		h := route.GetHandler()
+		if h == nil {
+			return nil
+		}
+		full, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		rel := relativeTemplate(full, versions)
+
+		var names []string
+		for _, name := range registry.Names() {
+			for _, path := range registry.Paths(name) {
+				if hasPathPrefix(full, path) || hasPathPrefix(rel, path) {
+					used[name+" "+path] = true
+					list, _ := registry.Get(name)
+					h = middleware.AccessList(list)(h)
+					names = append(names, "access-list:"+name)
+					break
+				}
+			}
+		}
+		if len(names) > 0 {
+			route.Handler(h)
+			a.Routes().Annotate(route, routetable.Meta{Middleware: names})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var unknown []string
+	for _, name := range registry.Names() {
+		for _, path := range registry.Paths(name) {
+			if !used[name+" "+path] {
+				unknown = append(unknown, name+" "+path)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("access list paths match no route: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// relativeTemplate strips the version path from a route template
+func relativeTemplate(full string, versions *versioning.Set) string {
+	for _, v := range versions.All() {
+		if rest, ok := strings.CutPrefix(full, v.Path); ok && (rest == "" || rest[0] == '/') {
+			return rest
+		}
+	}
+	return full
+}
+
+// hasPathPrefix reports whether template is prefix or one of its sub-paths
+func hasPathPrefix(template, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return template == prefix || strings.HasPrefix(template, prefix+"/")
+}
+
+// matchPolicy returns the most specific policy key of a route method: its
+// name, then method and template, then the template alone
+func matchPolicy(policies map[string]config.RoutePolicy, name, method, full, rel string) (string, bool) {
+	if _, ok := policies[name]; ok && name != "" {
+		return name, true
+	}
+	best, rank := "", 0
+	for key := range policies {
+		keyMethod, tpl, found := strings.Cut(key, " ")
+		if !found {
+			keyMethod, tpl = "", key
+		} else if keyMethod != method {
+			continue
+		}
+		r := 0
+		switch {
+		case keyMethod != "" && routetable.SamePattern(tpl, full):
+			r = 4
+		case keyMethod != "" && routetable.SamePattern(tpl, rel):
+			r = 3
+		case keyMethod == "" && routetable.SamePattern(tpl, full):
+			r = 2
+		case keyMethod == "" && routetable.SamePattern(tpl, rel):
+			r = 1
+		}
+		if r > rank || (r == rank && r > 0 && key < best) {
+			best, rank = key, r
+		}
+	}
+	return best, rank > 0
+}
+
+// wrapRoutePolicy applies policy to h and returns the names of the applied
+// middleware for the route table
+func wrapRoutePolicy(h http.Handler, policy config.RoutePolicy, verifier *auth.Verifier) (http.Handler, []string) {
+	if !policy.IsEnabled() {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeNotFound, errors.New("route disabled by configuration")))
+		}), []string{"disabled"}
+	}
+
+	// De fuera hacia dentro
+	var (
+		chain []func(http.Handler) http.Handler
+		names []string
+	)
+	if len(policy.Headers) > 0 {
+		chain = append(chain, middleware.HeaderOverrides(policy.Headers))
+		names = append(names, "headers")
+	}
+	if d, err := time.ParseDuration(policy.Timeout); err == nil && d > 0 {
+		chain = append(chain, middleware.Timeout(d))
+		names = append(names, "timeout")
+	}
+	switch policy.Auth {
+	case config.RouteAuthJWT:
+		chain = append(chain, middleware.JWTAuth(verifier, false))
+		names = append(names, "jwt")
+	case config.RouteAuthOptional:
+		chain = append(chain, middleware.JWTAuth(verifier, true))
+		names = append(names, "jwt-optional")
+	}
+	if policy.Permission != "" {
+		chain = append(chain, middleware.RequirePermission(policy.Permission))
+		names = append(names, "permission:"+policy.Permission)
+	}
+	if rl := policy.RateLimit; rl != nil {
+		chain = append(chain, middleware.RateLimit(ratelimit.New(rl.RPS, rl.Burst), rl.Key))
+		names = append(names, "rate-limit")
+	}
+	if policy.MaxBody > 0 {
+		chain = append(chain, middleware.MaxBody(policy.MaxBody))
+		names = append(names, "max-body")
+	}
+	if d, err := time.ParseDuration(policy.CacheTTL); err == nil && d > 0 {
+		chain = append(chain, middleware.CacheControl(d))
+		names = append(names, "cache")
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		h = chain[i](h)
+	}
+	return h, names
+}
+
+// documentRoutePolicy reflects policy in the OpenAPI operation of the route
+func documentRoutePolicy(reg *openapi.Registry, method, path string, policy config.RoutePolicy) {
+	if !policy.IsEnabled() {
+		reg.Remove(method, path)
+		return
+	}
+	reg.Update(method, path, func(op *openapi.Operation) {
+		add := func(codes ...apperrors.Code) {
+			for _, code := range codes {
+				if !slices.Contains(op.Errors, code) {
+					op.Errors = append(op.Errors, code)
+				}
+			}
+		}
+		if policy.Timeout != "" {
+			add(apperrors.CodeGatewayTimeout)
+		}
+		if policy.Auth != config.RouteAuthNone {
+			op.Auth = openapi.AuthBearer
+			add(apperrors.CodeUnauthorized, apperrors.CodeInvalidToken, apperrors.CodeTokenExpired)
+		}
+		if policy.Permission != "" {
+			add(apperrors.CodeForbidden)
+		}
+		if policy.RateLimit != nil {
+			add(apperrors.CodeTooManyRequests)
+		}
+		if policy.MaxBody > 0 {
+			add(apperrors.CodePayloadTooLarge)
+		}
+	})
+}
+
+// samePolicy reports whether every method of a route resolved to one key
+func samePolicy(keys map[string]string) bool {
+	first := ""
+	for _, key := range keys {
+		if first == "" {
+			first = key
+		} else if key != first {
+			return false
+		}
+	}
+	return true
+}
+
+// methodPolicies dispatches to the handler of the request method, or to
+// fallback for methods without a policy
+func methodPolicies(byMethod map[string]http.Handler, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, ok := byMethod[r.Method]; ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		fallback.ServeHTTP(w, r)
+	})
+}
@@ -1,11 +1,18 @@
 package http
 
 import (
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
 	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
 	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes"
 	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/metrics"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/versioning"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -23,21 +30,182 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
-// NewRouter creates a new HTTP router with all the routes
-func NewRouter(a *models.Application) *mux.Router {
+// NewRouter creates a new HTTP router with all the routes. It fails when a
+// route is registered twice or is shadowed by an earlier one.
+func NewRouter(a *models.Application) (*mux.Router, error) {
 	r := mux.NewRouter()
+	table := a.Routes()
+	versions := versioning.New(a.Configs().HTTP)
+
+	// Cada versión se monta junto al base path; las rutas que una versión no
+	// redefine se heredan de la anterior
+	var (
+		previous    *mux.Router
+		previousVer versioning.Version
+		routers     = make(map[string]http.Handler)
+		exempt      = []string{versions.Root() + constants.HEALTH_CHECK}
+	)
+	for i, v := range versions.All() {
+		api := r.PathPrefix(v.Path).Subrouter()
+		table.Use(api, "api-version", middleware.APIVersion(v))
+		if i == 0 {
+			routes.SetupCoreRoutes(api, a)
+		}
+		routes.SetupRoutes(api, a, v.Name, i == 0)
+		if previous != nil {
+			for _, route := range table.Inherit(previous, api, previousVer.Path, v.Path) {
+				for _, method := range route.Methods {
+					a.OpenAPI().Alias(method, route.From, route.To)
+				}
+			}
+		}
+		previous, previousVer = api, v
+		routers[v.Name] = api
+		exempt = append(exempt, v.Path+constants.HEALTH_CHECK)
+	}
+
+	// Políticas de la sección routes y listas de acceso por grupo de rutas
+	if err := applyRoutePolicies(a, r, versions); err != nil {
+		return nil, err
+	}
+	if err := applyAccessLists(a, r, versions); err != nil {
+		return nil, err
+	}
+
+	// Rutas sin versión: Accept-Version o la versión por defecto
+	r.PathPrefix(versions.Root()).Handler(middleware.VersionDispatcher(versions, routers))
 
-	// Use the base path from config
-	api := r.PathPrefix(a.Configs().HTTP.BasePath).Subrouter()
+	// Add middleware
+	table.Use(r, "tracing", middleware.TracingMiddleware)
+	table.Use(r, "request-id", middleware.RequestIDMiddleware)
+	table.Use(r, "client-address", middleware.ClientAddressMiddleware(a.Configs().HTTP.TrustedProxyPrefixes()))
+	if a.Configs().HTTP.Security.IsEnabled() {
+		table.Use(r, "security-headers", middleware.SecurityHeaders(securityHeaders(a.Configs().HTTP)))
+	}
+	table.Use(r, "language", middleware.LanguageMiddleware)
+	table.Use(r, "client-cert", middleware.ClientCertMiddleware)
+	table.Use(r, "logging", loggingMiddleware)
+	if MetricsEnabled(a) {
+		table.Use(r, "metrics", middleware.MetricsMiddleware)
+	}
+	table.Use(r, "features", middleware.FeatureContextMiddleware)
+	table.Use(r, "maintenance", middleware.MaintenanceMiddleware(a.Features(), a.Configs().App.Features.MaintenanceRetryAfter,
+		exempt...,
+	))
+	table.Use(r, "cors-methods", mux.CORSMethodMiddleware(r))
+
+	if err := buildRouteTable(table, "http", r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
 
-	routes.SetupRoutes(api, a)
+// NewAdminRouter creates the router of the admin listener. It is kept apart
+// from the public router and its base path, and every route requires the
+// admin token.
+func NewAdminRouter(a *models.Application) (*mux.Router, error) {
+	r := mux.NewRouter()
+	table := a.Routes()
+	routes.SetupAdminRoutes(r, a)
+
+	table.Use(r, "request-id", middleware.RequestIDMiddleware)
+	table.Use(r, "client-address", middleware.ClientAddressMiddleware(a.Configs().HTTP.TrustedProxyPrefixes()))
+	if a.Configs().HTTP.Security.IsEnabled() {
+		table.Use(r, "security-headers", middleware.SecurityHeaders(securityHeaders(a.Configs().HTTP)))
+	}
+	table.Use(r, "language", middleware.LanguageMiddleware)
+	table.Use(r, "logging", loggingMiddleware)
+	if list, ok := a.AccessLists().Get(a.Configs().Admin.AccessList); ok {
+		table.Use(r, "access-list:"+list.Name(), middleware.AccessList(list))
+	}
+
+	if err := buildRouteTable(table, "admin", r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
 
-	// Add middleware
-	r.Use(middleware.RequestIDMiddleware)
-	r.Use(loggingMiddleware)
-	r.Use(mux.CORSMethodMiddleware(r))
+// securityHeaders builds the hardening headers of the http section. HSTS is
+// only sent when the listener serves TLS.
+func securityHeaders(cfg config.HTTPConfig) middleware.SecurityHeadersOptions {
+	sec := cfg.Security
+	headers := http.Header{}
+	headers.Set("X-Content-Type-Options", "nosniff")
+	headers.Set("X-Frame-Options", sec.FrameOptions)
+	headers.Set("Referrer-Policy", sec.ReferrerPolicy)
+	headers.Set("Content-Security-Policy", sec.ContentSecurityPolicy)
+	if cfg.TLS.Enabled {
+		hsts := "max-age=" + strconv.Itoa(sec.HSTSMaxAge)
+		if sec.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if sec.HSTSPreload {
+			hsts += "; preload"
+		}
+		headers.Set("Strict-Transport-Security", hsts)
+	}
+	return middleware.SecurityHeadersOptions{Headers: headers, Strip: sec.StripHeaders}
+}
 
-	return r
+// buildRouteTable walks the routes of a listener and logs the resulting table
+func buildRouteTable(table *routetable.Table, listener string, r *mux.Router) error {
+	mounted, err := table.Build(listener, r)
+	if err != nil {
+		return err
+	}
+	for _, route := range mounted {
+		logger.Log.Info("Route mounted",
+			zap.String("listener", listener),
+			zap.Strings("methods", route.Methods),
+			zap.String("path", route.Path),
+			zap.Bool("prefix", route.Prefix),
+			zap.String("module", route.Module),
+			zap.Strings("middleware", route.Middleware),
+			zap.String("auth", route.Auth),
+		)
+	}
+	return nil
+}
+
+// NewAdminServer creates the admin listener on admin.address, or nil when
+// the admin server is disabled
+func NewAdminServer(a *models.Application) (*http.Server, error) {
+	cfg := a.Configs().Admin
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	router, err := NewAdminRouter(a)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Server{
+		Addr:              cfg.Address,
+		Handler:           router,
+		ReadHeaderTimeout: 5 * time.Second,
+		// No write timeout: CPU profiles and traces stream for their full duration
+	}, nil
+}
+
+// MetricsEnabled reports whether Prometheus metrics are enabled both in the
+// observability section and through the enable_metrics feature flag
+func MetricsEnabled(a *models.Application) bool {
+	return a.Configs().Observability.Metrics.Enabled && a.Features().Enabled(features.EnableMetrics)
+}
+
+// TracingEnabled reports whether span export is enabled both in the
+// observability section and through the enable_tracing feature flag
+func TracingEnabled(a *models.Application) bool {
+	return a.Configs().Observability.Tracing.Enabled && a.Features().Enabled(features.EnableTracing)
+}
+
+// NewMetricsServer creates the listener serving Prometheus metrics on
+// observability.metrics.port, or nil when metrics are disabled
+func NewMetricsServer(a *models.Application) *http.Server {
+	if !MetricsEnabled(a) {
+		return nil
+	}
+	cfg := a.Configs().Observability.Metrics
+	return metrics.NewServer(cfg.Port, cfg.Path)
 }
 
 // loggingMiddleware registra información detallada de cada petición HTTP
@@ -54,16 +222,13 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		// Calcular la duración
 		duration := time.Since(start)
 
-		// Obtener el request ID del contexto
-		requestID := middleware.GetRequestID(r.Context())
-
-		// Registrar la información de la petición
-		logger := logger.Logger().With(
+		// Registrar la información de la petición; el logger del contexto ya
+		// incluye el request ID y el trace ID
+		logger := logger.FromContext(r.Context()).With(
 			zap.String("method", r.Method),
 			zap.String("path", r.URL.Path),
 			zap.Duration("duration", duration),
 			zap.Int("status", lrw.statusCode),
-			zap.String("request_id", requestID),
 		)
 
 		// Usar nivel de log apropiado basado en el status code
@@ -0,0 +1,133 @@
+// Package modules defines the pluggable business modules of the service. Each
+// module registers itself from an init function and is enabled or disabled
+// through the modules section of the configuration, so new domains plug in
+// without editing the router. A module in a new package still needs a blank
+// import in internal/interfaces/routes/routes.go so its init runs. The health
+// probes are not a module and cannot be disabled.
+package modules
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/health"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/lifecycle"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// Module is a self-contained business domain
+type Module interface {
+	// Name identifies the module in the configuration and the logs
+	Name() string
+	// Init resolves the module dependencies from the application
+	Init(a *models.Application) error
+	// RegisterRoutes mounts the module routes under the API base path
+	RegisterRoutes(router *mux.Router)
+	// Health returns the checks the module contributes to readiness
+	Health() []Check
+	// Shutdown releases the module resources once the servers have drained
+	Shutdown(ctx context.Context) error
+}
+
+// VersionedModule is implemented by modules that add or replace routes in a
+// newer API version. RegisterVersionRoutes is called for every version after
+// the oldest one with the router of that version; the routes it does not
+// register fall through to the previous version.
+type VersionedModule interface {
+	RegisterVersionRoutes(version string, router *mux.Router)
+}
+
+// Check is a health check contributed by a module
+type Check struct {
+	Name     string
+	Critical bool
+	Checker  health.Checker
+}
+
+var (
+	mu          sync.Mutex
+	registered  = make(map[string]Module)
+	initialized []Module
+)
+
+// Register adds a module to the registry. It is meant to be called from an
+// init function and panics on duplicate names.
+func Register(m Module) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := registered[m.Name()]; exists {
+		panic(fmt.Sprintf("modules: module %q registered twice", m.Name()))
+	}
+	registered[m.Name()] = m
+}
+
+// Names returns the names of every registered module, sorted
+func Names() []string {
+	mu.Lock()
+	defer mu.Unlock()
+	return sortedNames()
+}
+
+// Init initializes the enabled modules in name order, registers their health
+// checks and adds a lifecycle hook that shuts each one down
+func Init(a *models.Application) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	initialized = nil
+	for _, name := range sortedNames() {
+		m := registered[name]
+		if !a.Configs().ModuleEnabled(name) {
+			logger.Log.Info("Module disabled", zap.String("module", name))
+			continue
+		}
+		if err := m.Init(a); err != nil {
+			return fmt.Errorf("failed to initialize module %s: %w", name, err)
+		}
+		for _, c := range m.Health() {
+			a.Health().Register(c.Name, c.Critical, c.Checker)
+		}
+		a.Lifecycle().Register(lifecycle.Hook{
+			Name:  "module:" + name,
+			Order: lifecycle.OrderWorkers,
+			Stop:  m.Shutdown,
+		})
+		initialized = append(initialized, m)
+		logger.Log.Info("Module initialized", zap.String("module", name))
+	}
+	return nil
+}
+
+// RegisterRoutes mounts the routes of every initialized module for an API
+// version and records the module as the owner of its routes in the route
+// table. The oldest version receives every module's RegisterRoutes; newer
+// versions only the routes of modules implementing VersionedModule.
+func RegisterRoutes(router *mux.Router, table *routetable.Table, version string, oldest bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, m := range initialized {
+		if oldest {
+			table.Own(router, m.Name(), func() { m.RegisterRoutes(router) })
+			continue
+		}
+		if vm, ok := m.(VersionedModule); ok {
+			table.Own(router, m.Name(), func() { vm.RegisterVersionRoutes(version, router) })
+		}
+	}
+}
+
+func sortedNames() []string {
+	names := make([]string, 0, len(registered))
+	for name := range registered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
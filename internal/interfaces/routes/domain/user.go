@@ -1,27 +1,205 @@
 package domainRoutes
 
 import (
+	"context"
+	"net/http"
+	"sync"
+
 	"api-ptf-core-business-orchestrator-go-ms/internal/application"
+	"api-ptf-core-business-orchestrator-go-ms/internal/domain"
 	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/repository"
 	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/modules"
 	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/container"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
 
 	"github.com/gorilla/mux"
 )
 
-func RegisterUserRoutes(router *mux.Router, a *models.Application) {
-	// Inicializar repositorios
-	userRepo := repository.NewMongoUserRepository(a.MongoDB())
+func init() {
+	modules.Register(&userModule{})
+}
 
-	// Inicializar servicios
-	userService := application.NewUserService(userRepo.Repo, userRepo)
+// userModule expone la gestión de usuarios. Sus dependencias se resuelven en
+// la primera petición, de modo que el módulo funciona aunque la aplicación
+// haya iniciado sin MongoDB.
+type userModule struct {
+	app *models.Application
 
-	// Initialize handlers
-	userHandler := handlers.NewUserHandler(userService)
+	mu       sync.Mutex
+	handlers *userHandlers
+}
+
+// userHandlers son los handlers ya construidos con sus dependencias
+type userHandlers struct {
+	list   http.Handler
+	create http.Handler
+	get    http.Handler
+	update http.Handler
+	patch  http.Handler
+	remove http.Handler
+}
+
+func (m *userModule) Name() string { return "users" }
+
+func (m *userModule) Init(a *models.Application) error {
+	m.app = a
+	return nil
+}
+
+func (m *userModule) RegisterRoutes(router *mux.Router) {
+	api := m.app.OpenAPI()
+	table := m.app.Routes()
 
 	// User routes
 	userRouter := router.PathPrefix("/users").Subrouter()
-	userRouter.HandleFunc("", userHandler.ListUsers).Methods("GET")
-	userRouter.HandleFunc("", userHandler.CreateUser).Methods("POST")
-	userRouter.HandleFunc("/{id}", userHandler.GetUserByID).Methods("GET")
+	api.Handle(userRouter, "", m.serve(func(h *userHandlers) http.Handler { return h.list }), openapi.Operation{
+		Method:      http.MethodGet,
+		OperationID: "listUsers",
+		Summary:     "List users with pagination",
+		Tags:        []string{"users"},
+		Params: []openapi.Param{
+			{Name: "page", In: "query", Description: "Page number (default: 1)", Type: int64(0)},
+			{Name: "limit", In: "query", Description: "Items per page (max: 100, default: 10)", Type: int64(0)},
+			{Name: "filter[email]", In: "query", Description: "Exact email"},
+			{Name: "filter[date_created][gte]", In: "query", Description: "Created at or after (RFC 3339 or YYYY-MM-DD); gt, lt and lte are also accepted"},
+			{Name: "sort", In: "query", Description: "Comma-separated email and date_created, - for descending (default: -date_created)"},
+			{Name: "cursor", In: "query", Description: "Cursor pagination: empty for the first page, then next_cursor or prev_cursor; allows a single sort field"},
+		},
+		Response: handlers.ListUsersResponse{Data: []domain.User{}},
+		Errors: []apperrors.Code{
+			apperrors.CodeInvalidPage, apperrors.CodeInvalidLimit, apperrors.CodeInvalidFilter, apperrors.CodeInvalidSort,
+			apperrors.CodeInvalidCursor, apperrors.CodeInternal, apperrors.CodeServiceUnavailable,
+		},
+	})
+	create := api.Handle(userRouter, "", m.serve(func(h *userHandlers) http.Handler { return h.create }), openapi.Operation{
+		Method:      http.MethodPost,
+		OperationID: "createUser",
+		Summary:     "Create a user",
+		Tags:        []string{"users"},
+		Params: []openapi.Param{
+			{Name: middleware.IdempotencyKeyHeader, In: "header", Description: "Makes retries safe; the stored response is replayed"},
+		},
+		Request:  handlers.CreateUserRequest{},
+		Response: domain.User{},
+		Status:   http.StatusCreated,
+		Errors: []apperrors.Code{
+			apperrors.CodeInvalidRequestBody, apperrors.CodeUserEmailTaken, apperrors.CodeConflict,
+			apperrors.CodeUnprocessableEntity, apperrors.CodeInternal, apperrors.CodeServiceUnavailable,
+		},
+	})
+	get := api.Handle(userRouter, "/{id}", m.serve(func(h *userHandlers) http.Handler { return h.get }), openapi.Operation{
+		Method:      http.MethodGet,
+		OperationID: "getUser",
+		Summary:     "Get a user by ID",
+		Tags:        []string{"users"},
+		Params: []openapi.Param{
+			{Name: "id", In: "path", Description: "User ID"},
+			{Name: "If-None-Match", In: "header", Description: "Returns 304 when the ETag still matches"},
+		},
+		Response: domain.User{},
+		Errors:   []apperrors.Code{apperrors.CodeUserInvalidID, apperrors.CodeUserNotFound, apperrors.CodeInternal, apperrors.CodeServiceUnavailable},
+	})
+
+	// Las escrituras aceptan If-Match contra el ETag del GET
+	ifMatch := openapi.Param{Name: "If-Match", In: "header", Description: "Returns 412 when the user changed since the given ETag"}
+	writeErrors := []apperrors.Code{
+		apperrors.CodeUserInvalidID, apperrors.CodeUserNotFound, apperrors.CodePreconditionFailed,
+		apperrors.CodeInternal, apperrors.CodeServiceUnavailable,
+	}
+	update := api.Handle(userRouter, "/{id}", m.serve(func(h *userHandlers) http.Handler { return h.update }), openapi.Operation{
+		Method:      http.MethodPut,
+		OperationID: "updateUser",
+		Summary:     "Replace a user; pass is optional and kept when omitted",
+		Tags:        []string{"users"},
+		Params:      []openapi.Param{{Name: "id", In: "path", Description: "User ID"}, ifMatch},
+		Request:     handlers.UpdateUserRequest{},
+		Response:    domain.User{},
+		Errors: append([]apperrors.Code{
+			apperrors.CodeInvalidRequestBody, apperrors.CodeUserInvalid, apperrors.CodeUserEmailTaken,
+		}, writeErrors...),
+	})
+	patch := api.Handle(userRouter, "/{id}", m.serve(func(h *userHandlers) http.Handler { return h.patch }), openapi.Operation{
+		Method:      http.MethodPatch,
+		OperationID: "patchUser",
+		Summary:     "Update a user with a JSON Merge Patch; only email and pass can change",
+		Tags:        []string{"users"},
+		Params:      []openapi.Param{{Name: "id", In: "path", Description: "User ID"}, ifMatch},
+		Request:     handlers.PatchUserRequest{},
+		Response:    domain.User{},
+		Errors: append([]apperrors.Code{
+			apperrors.CodeInvalidRequestBody, apperrors.CodeUnsupportedMedia, apperrors.CodeUserInvalid, apperrors.CodeUserEmailTaken,
+		}, writeErrors...),
+	})
+	remove := api.Handle(userRouter, "/{id}", m.serve(func(h *userHandlers) http.Handler { return h.remove }), openapi.Operation{
+		Method:      http.MethodDelete,
+		OperationID: "deleteUser",
+		Summary:     "Delete a user",
+		Tags:        []string{"users"},
+		Params:      []openapi.Param{{Name: "id", In: "path", Description: "User ID"}, ifMatch},
+		Errors:      writeErrors,
+	})
+
+	// Los middleware se aplican al resolver los handlers; se declaran para la tabla de rutas
+	table.Annotate(create, routetable.Meta{Middleware: []string{"idempotency"}})
+	for _, route := range []*mux.Route{get, update, patch, remove} {
+		table.Annotate(route, routetable.Meta{Middleware: []string{"etag"}})
+	}
+}
+
+// serve delega en el handler elegido por pick, o responde 503 mientras sus
+// dependencias no puedan construirse
+func (m *userModule) serve(pick func(*userHandlers) http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, err := m.resolve()
+		if err != nil {
+			_ = utils.WriteError(w, r, apperrors.New(apperrors.CodeServiceUnavailable, err))
+			return
+		}
+		pick(h).ServeHTTP(w, r)
+	})
 }
+
+// resolve construye los handlers una sola vez desde el contenedor
+func (m *userModule) resolve() (*userHandlers, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.handlers != nil {
+		return m.handlers, nil
+	}
+
+	// Resolver dependencias desde el contenedor
+	userService, err := container.Resolve[*application.UserService](m.app.Container())
+	if err != nil {
+		return nil, err
+	}
+	idempotencyRepo, err := container.Resolve[*repository.MongoIdempotencyRepository](m.app.Container())
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize handlers
+	userHandler := handlers.NewUserHandler(userService)
+	get := http.HandlerFunc(userHandler.GetUserByID)
+	// If-Match se evalúa contra la representación actual que devuelve el GET
+	conditional := middleware.ETag(middleware.ETagOptions{Current: get})
+	m.handlers = &userHandlers{
+		list:   http.HandlerFunc(userHandler.ListUsers),
+		create: middleware.Idempotency(idempotencyRepo, m.app.Configs().App.Idempotency.MaxBody)(http.HandlerFunc(userHandler.CreateUser)),
+		get:    middleware.ETag(middleware.ETagOptions{})(get),
+		update: conditional(http.HandlerFunc(userHandler.UpdateUser)),
+		patch:  conditional(http.HandlerFunc(userHandler.PatchUser)),
+		remove: conditional(http.HandlerFunc(userHandler.DeleteUser)),
+	}
+	return m.handlers, nil
+}
+
+// Health no agrega chequeos: MongoDB ya se verifica como infraestructura
+func (m *userModule) Health() []modules.Check { return nil }
+
+func (m *userModule) Shutdown(ctx context.Context) error { return nil }
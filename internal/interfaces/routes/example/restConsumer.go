@@ -1,17 +1,54 @@
 package example
 
 import (
+	"context"
+	"net/http"
+
 	handlers "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers/example"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/modules"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
 
 	"github.com/gorilla/mux"
 )
 
-func RegisterExampleRoutes(router *mux.Router) {
+func init() {
+	modules.Register(&exampleModule{})
+}
+
+// exampleModule expone los ejemplos de consumo de servicios REST
+type exampleModule struct {
+	api            *openapi.Registry
+	exampleHandler *handlers.ExampleHandler
+}
+
+func (m *exampleModule) Name() string { return "example" }
+
+func (m *exampleModule) Init(a *models.Application) error {
+	m.api = a.OpenAPI()
+
 	// Initialize handlers
-	exampleHandler := handlers.NewExampleHandler()
+	m.exampleHandler = handlers.NewExampleHandler()
+	return nil
+}
 
+func (m *exampleModule) RegisterRoutes(router *mux.Router) {
 	subrouter := router.PathPrefix(constants.REST_CLIENT_GROUP).Subrouter()
-	subrouter.HandleFunc("/characters", exampleHandler.GetAllCharacters).Methods(constants.GET)
-	subrouter.HandleFunc("/planets", exampleHandler.GetAllPlanets).Methods(constants.GET)
+	m.api.Handle(subrouter, "/characters", http.HandlerFunc(m.exampleHandler.GetAllCharacters), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "getAllCharacters",
+		Summary:     "Characters from the example REST integration",
+		Tags:        []string{"examples"},
+	})
+	m.api.Handle(subrouter, "/planets", http.HandlerFunc(m.exampleHandler.GetAllPlanets), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "getAllPlanets",
+		Summary:     "Planets from the example REST integration",
+		Tags:        []string{"examples"},
+	})
 }
+
+func (m *exampleModule) Health() []modules.Check { return nil }
+
+func (m *exampleModule) Shutdown(ctx context.Context) error { return nil }
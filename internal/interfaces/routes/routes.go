@@ -1,18 +1,32 @@
 package routes
 
 import (
-	uD "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes/domain"
-	eX "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes/example"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/modules"
 	uR "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes/utils"
 	"api-ptf-core-business-orchestrator-go-ms/internal/models"
 
+	// Los módulos de negocio se registran en su init; cada paquete nuevo de
+	// módulos necesita su import en blanco aquí
+	_ "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes/domain"
+	_ "api-ptf-core-business-orchestrator-go-ms/internal/interfaces/routes/example"
+
 	"github.com/gorilla/mux"
 )
 
-// SetupRoutes configura todas las rutas
-func SetupRoutes(router *mux.Router, a *models.Application) {
-	uR.RegisterInfoRoutes(router, a)
-	uR.RegisterRysncRoutes(router)
-	uD.RegisterUserRoutes(router, a)
-	eX.RegisterExampleRoutes(router)
+// SetupAdminRoutes configura las rutas del listener de administración
+func SetupAdminRoutes(router *mux.Router, a *models.Application) {
+	a.Routes().Own(router, "admin", func() { uR.RegisterAdminRoutes(router, a) })
+}
+
+// SetupCoreRoutes configura las sondas de salud en la versión más antigua de
+// la API. Pertenecen al núcleo y no a un módulo, así que deshabilitar módulos
+// nunca quita liveness ni readiness.
+func SetupCoreRoutes(router *mux.Router, a *models.Application) {
+	a.Routes().Own(router, "core", func() { uR.RegisterInfoRoutes(router, a) })
+}
+
+// SetupRoutes configura las rutas de los módulos habilitados para una versión
+// de la API; oldest indica la primera versión, que registra todas las rutas
+func SetupRoutes(router *mux.Router, a *models.Application, version string, oldest bool) {
+	modules.RegisterRoutes(router, a.Routes(), version, oldest)
 }
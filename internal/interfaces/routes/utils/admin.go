@@ -0,0 +1,43 @@
+package utilsRoutes
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAdminRoutes registers the administrative endpoints. They are served
+// by the admin listener only, never by the public API router.
+func RegisterAdminRoutes(router *mux.Router, a *models.Application) {
+	a.Routes().UseAuth(router, "admin-token", middleware.AdminAuthMiddleware(a.Configs().Admin.Token))
+
+	featuresHandler := handlers.NewFeaturesHandler(a.Features())
+
+	subrouter := router.PathPrefix(constants.ADMIN_GROUP).Subrouter()
+	subrouter.HandleFunc(constants.FEATURES, featuresHandler.ListFeatures).Methods(constants.GET)
+	subrouter.HandleFunc(constants.FEATURES+"/{name}", featuresHandler.GetFeature).Methods(constants.GET)
+	subrouter.HandleFunc(constants.FEATURES+"/{name}", featuresHandler.SetFeature).Methods(constants.PUT)
+	subrouter.HandleFunc(constants.FEATURES+"/{name}", featuresHandler.ResetFeature).Methods(constants.DELETE)
+	subrouter.HandleFunc(constants.RUNTIME, handlers.RuntimeStats).Methods(constants.GET)
+	subrouter.HandleFunc(constants.BUILD_INFO, handlers.BuildInfo).Methods(constants.GET)
+	subrouter.HandleFunc(constants.GOROUTINES, handlers.GoroutineDump).Methods(constants.GET)
+	subrouter.HandleFunc(constants.HEAP_DUMP, handlers.HeapDump).Methods(constants.GET)
+	subrouter.HandleFunc(constants.GC, handlers.ForceGC).Methods(constants.POST)
+	subrouter.HandleFunc(constants.ROUTES, func(w http.ResponseWriter, r *http.Request) {
+		handlers.RouteTable(w, r, a.Routes())
+	}).Methods(constants.GET)
+
+	// net/http/pprof; named profiles (heap, goroutine, allocs...) go through Index
+	pprofRouter := router.PathPrefix(constants.PPROF_GROUP).Subrouter()
+	pprofRouter.HandleFunc("/cmdline", pprof.Cmdline)
+	pprofRouter.HandleFunc("/profile", pprof.Profile)
+	pprofRouter.HandleFunc("/symbol", pprof.Symbol)
+	pprofRouter.HandleFunc("/trace", pprof.Trace)
+	pprofRouter.PathPrefix("/").HandlerFunc(pprof.Index)
+}
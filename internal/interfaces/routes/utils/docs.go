@@ -0,0 +1,26 @@
+package utilsRoutes
+
+import (
+	"net/http"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers"
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/middleware"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterDocsRoutes publica el documento OpenAPI y Swagger UI bajo el base path
+func RegisterDocsRoutes(router *mux.Router, a *models.Application) {
+	router.HandleFunc(constants.OPENAPI, func(w http.ResponseWriter, r *http.Request) {
+		handlers.OpenAPIDocument(w, r, a)
+	}).Methods(constants.GET)
+	// Swagger UI carga sus assets de unpkg.com; su CSP se relaja solo en esta ruta
+	csp := middleware.HeaderOverrides(map[string]string{
+		"Content-Security-Policy": a.Configs().HTTP.Security.DocsContentSecurityPolicy,
+	})
+	docs := router.Handle(constants.DOCS, csp(http.HandlerFunc(handlers.SwaggerUI))).Methods(constants.GET)
+	a.Routes().Annotate(docs, routetable.Meta{Middleware: []string{"docs-csp"}})
+}
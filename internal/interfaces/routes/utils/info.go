@@ -6,13 +6,41 @@ import (
 	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers"
 	"api-ptf-core-business-orchestrator-go-ms/internal/models"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/health"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
 
 	"github.com/gorilla/mux"
 )
 
 func RegisterInfoRoutes(router *mux.Router, a *models.Application) {
+	api := a.OpenAPI()
 	subrouter := router.PathPrefix(constants.UTILS_GROUP).Subrouter()
-	subrouter.HandleFunc(constants.HEALTH_CHECK, func(w http.ResponseWriter, r *http.Request) {
+	api.Handle(subrouter, constants.HEALTH_CHECK, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handlers.Health(w, r, a)
-	}).Methods(constants.GET)
+	}), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "health",
+		Summary:     "Detailed health report",
+		Description: "Status, latency and last error of every registered check. Returns 503 when a critical check is down.",
+		Tags:        []string{"health"},
+		Response:    handlers.HealthReport{},
+	})
+	api.Handle(subrouter, constants.LIVENESS, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlers.Liveness(w, r, a)
+	}), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "liveness",
+		Summary:     "Liveness probe",
+		Tags:        []string{"health"},
+	})
+	api.Handle(subrouter, constants.READINESS, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlers.Readiness(w, r, a)
+	}), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "readiness",
+		Summary:     "Readiness probe",
+		Description: "Returns 503 with the check results when a critical check is down.",
+		Tags:        []string{"health"},
+		Response:    []health.Result{},
+	})
 }
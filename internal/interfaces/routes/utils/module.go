@@ -0,0 +1,36 @@
+package utilsRoutes
+
+import (
+	"context"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/modules"
+	"api-ptf-core-business-orchestrator-go-ms/internal/models"
+
+	"github.com/gorilla/mux"
+)
+
+func init() {
+	modules.Register(&utilsModule{})
+}
+
+// utilsModule expone rsync y la documentación de la API. Las sondas de salud
+// las monta el núcleo (routes.SetupCoreRoutes) para que no dependan de él.
+type utilsModule struct {
+	app *models.Application
+}
+
+func (m *utilsModule) Name() string { return "utils" }
+
+func (m *utilsModule) Init(a *models.Application) error {
+	m.app = a
+	return nil
+}
+
+func (m *utilsModule) RegisterRoutes(router *mux.Router) {
+	RegisterRysncRoutes(router, m.app.OpenAPI())
+	RegisterDocsRoutes(router, m.app)
+}
+
+func (m *utilsModule) Health() []modules.Check { return nil }
+
+func (m *utilsModule) Shutdown(ctx context.Context) error { return nil }
@@ -1,13 +1,21 @@
 package utilsRoutes
 
 import (
+	"net/http"
+
 	"api-ptf-core-business-orchestrator-go-ms/internal/interfaces/http/handlers"
 	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/constants"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
 
 	"github.com/gorilla/mux"
 )
 
-func RegisterRysncRoutes(router *mux.Router) {
+func RegisterRysncRoutes(router *mux.Router, api *openapi.Registry) {
 	subrouter := router.PathPrefix(constants.UTILS_GROUP).Subrouter()
-	subrouter.HandleFunc(constants.RSYNC, handlers.Rysnc).Methods(constants.GET)
+	api.Handle(subrouter, constants.RSYNC, http.HandlerFunc(handlers.Rysnc), openapi.Operation{
+		Method:      constants.GET,
+		OperationID: "rsync",
+		Summary:     "Trigger a configuration rsync",
+		Tags:        []string{"utils"},
+	})
 }
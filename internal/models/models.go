@@ -1,21 +1,83 @@
 package models
 
 import (
+	"sync"
+	"time"
+
 	"api-ptf-core-business-orchestrator-go-ms/internal/config"
 	"api-ptf-core-business-orchestrator-go-ms/internal/infrastructure/database"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/accesslist"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/container"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/features"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/health"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/lifecycle"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/openapi"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/routetable"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 5 * time.Second
+	defaultShutdown       = 30 * time.Second
 )
 
 type Application struct {
-	cfg *config.Config
-	db  *database.Database
+	cfg       *config.Config
+	dbMu      sync.RWMutex
+	db        *database.Database
+	features  *features.Store
+	health    *health.Registry
+	lifecycle *lifecycle.Manager
+	container *container.Container
+	openapi   *openapi.Registry
+	routes    *routetable.Table
+	access    *accesslist.Registry
 }
 
-// NewApplication creates a new Application instance with the provided dependencies
+// NewApplication creates a new Application instance with the provided
+// dependencies. db may be nil when starting in degraded mode; it is set with
+// SetDB once MongoDB becomes reachable.
 func NewApplication(cfg *config.Config, db *database.Database) *Application {
-	return &Application{
-		cfg: cfg,
-		db:  db,
+	a := &Application{
+		cfg:      cfg,
+		db:       db,
+		features: features.NewStore(cfg.App.Features, cfg.JSONConfig),
+		health: health.NewRegistry(
+			config.ParseDuration(cfg.Health.CheckInterval, defaultHealthInterval),
+			config.ParseDuration(cfg.Health.Timeout, defaultHealthTimeout),
+		),
+		lifecycle: lifecycle.NewManager(config.ParseDuration(cfg.Timeouts.Shutdown, defaultShutdown)),
+		container: container.New(),
+		openapi:   openapi.NewRegistry(),
+		routes:    routetable.New(),
+	}
+
+	// Las reglas ya se validaron al cargar la configuración
+	var params []config.Parameter
+	if cfg.JSONConfig != nil {
+		params = cfg.JSONConfig.Params
 	}
+	access, err := accesslist.New(cfg.AccessLists, params, cfg.App.JSONConfigPath)
+	if err != nil {
+		logger.Log.Error("Invalid access list params, using the YAML rules", zap.Error(err))
+	}
+	a.access = access
+
+	// Componentes base disponibles para los providers
+	container.Value(a.container, cfg)
+	container.Provide(a.container, func(*container.Container) (*database.Database, error) {
+		// Falla hasta que haya conexión; el contenedor reintenta en la próxima resolución
+		if db := a.MongoDB(); db != nil {
+			return db, nil
+		}
+		return nil, database.ErrUnavailable
+	})
+	container.Value(a.container, a.features)
+	container.Value(a.container, a.health)
+	return a
 }
 
 // NewEmptyApplication creates a new empty Application instance
@@ -25,6 +87,8 @@ func NewEmptyApplication() *Application {
 
 // DB returns the database instance
 func (a *Application) MongoDB() *database.Database {
+	a.dbMu.RLock()
+	defer a.dbMu.RUnlock()
 	return a.db
 }
 
@@ -33,6 +97,46 @@ func (a *Application) Configs() *config.Config {
 	return a.cfg
 }
 
+// Features returns the feature flag store
+func (a *Application) Features() *features.Store {
+	return a.features
+}
+
+// Health returns the health check registry
+func (a *Application) Health() *health.Registry {
+	return a.health
+}
+
+// Lifecycle returns the manager of startup and shutdown hooks
+func (a *Application) Lifecycle() *lifecycle.Manager {
+	return a.lifecycle
+}
+
+// Container returns the dependency container
+func (a *Application) Container() *container.Container {
+	return a.container
+}
+
+// OpenAPI returns the registry of documented routes
+func (a *Application) OpenAPI() *openapi.Registry {
+	return a.openapi
+}
+
+// Routes returns the table of mounted routes
+func (a *Application) Routes() *routetable.Table {
+	return a.routes
+}
+
+// AccessLists returns the IP access lists
+func (a *Application) AccessLists() *accesslist.Registry {
+	return a.access
+}
+
+// ShutdownTimeout returns the total time allowed for a graceful shutdown
+func (a *Application) ShutdownTimeout() time.Duration {
+	return config.ParseDuration(a.cfg.Timeouts.Shutdown, defaultShutdown)
+}
+
 // SetConfig sets the configuration instance
 func (a *Application) SetConfig(cfg *config.Config) {
 	a.cfg = cfg
@@ -40,5 +144,7 @@ func (a *Application) SetConfig(cfg *config.Config) {
 
 // SetDB sets the database instance
 func (a *Application) SetDB(db *database.Database) {
+	a.dbMu.Lock()
+	defer a.dbMu.Unlock()
 	a.db = db
 }
@@ -0,0 +1,261 @@
+// Package accesslist evaluates named IP allow and deny lists. Rules come from
+// the YAML configuration and can be replaced at runtime from the JSON config
+// params, which are reloaded when the file changes.
+package accesslist
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ParamPrefix is the prefix of the JSON config params that replace the rules
+// of a list: access_list.<name>.allow and access_list.<name>.deny
+const ParamPrefix = "access_list."
+
+// Reasons a request is rejected
+const (
+	ReasonDenied     = "denied"
+	ReasonNotAllowed = "not_allowed"
+)
+
+// rules is an immutable snapshot of the CIDRs of a list
+type rules struct {
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+// List is a named set of allow and deny rules. Deny rules win; when allow
+// rules exist, clients outside them are rejected.
+type List struct {
+	name  string
+	rules atomic.Pointer[rules]
+}
+
+// Name returns the name of the list
+func (l *List) Name() string { return l.name }
+
+// Check reports whether ip may pass and, when it may not, the reason. An
+// invalid IP only passes lists without allow rules.
+func (l *List) Check(ip netip.Addr) (bool, string) {
+	r := l.rules.Load()
+	if contains(r.deny, ip) {
+		return false, ReasonDenied
+	}
+	if len(r.allow) > 0 && !contains(r.allow, ip) {
+		return false, ReasonNotAllowed
+	}
+	return true, ""
+}
+
+// Rules returns the current allow and deny CIDRs
+func (l *List) Rules() (allow, deny []string) {
+	r := l.rules.Load()
+	return prefixStrings(r.allow), prefixStrings(r.deny)
+}
+
+func (l *List) set(allow, deny []string) error {
+	r := &rules{}
+	var err error
+	if r.allow, err = parseAll(allow); err != nil {
+		return fmt.Errorf("access list %s: %w", l.name, err)
+	}
+	if r.deny, err = parseAll(deny); err != nil {
+		return fmt.Errorf("access list %s: %w", l.name, err)
+	}
+	l.rules.Store(r)
+	return nil
+}
+
+// Registry holds the access lists of the configuration
+type Registry struct {
+	cfg   config.AccessListsConfig
+	lists map[string]*List
+
+	mu       sync.Mutex
+	path     string
+	interval time.Duration
+	modTime  time.Time
+}
+
+// New creates the lists of cfg with the rules of the YAML, replaced by those
+// of params when present. jsonPath is the JSON config file watched by Start.
+// When params hold invalid rules the registry keeps the YAML rules and the
+// error is returned along with it.
+func New(cfg config.AccessListsConfig, params []config.Parameter, jsonPath string) (*Registry, error) {
+	interval, err := time.ParseDuration(cfg.ReloadInterval)
+	if err != nil || interval <= 0 {
+		interval = 30 * time.Second
+	}
+	reg := &Registry{cfg: cfg, lists: make(map[string]*List, len(cfg.Lists)), path: jsonPath, interval: interval}
+	for name, list := range cfg.Lists {
+		reg.lists[name] = &List{name: name}
+		if err := reg.lists[name].set(list.Allow, list.Deny); err != nil {
+			return nil, err
+		}
+	}
+	if jsonPath != "" {
+		if info, err := os.Stat(jsonPath); err == nil {
+			reg.modTime = info.ModTime()
+		}
+	}
+	return reg, reg.Load(params)
+}
+
+// Get returns the list called name
+func (reg *Registry) Get(name string) (*List, bool) {
+	l, ok := reg.lists[name]
+	return l, ok
+}
+
+// Names returns the names of the lists, sorted
+func (reg *Registry) Names() []string {
+	names := make([]string, 0, len(reg.lists))
+	for name := range reg.lists {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Paths returns the route group of the list called name
+func (reg *Registry) Paths(name string) []string {
+	return reg.cfg.Lists[name].Paths
+}
+
+// Load applies the rules of the YAML and params to every list. Rules are
+// validated first, so a bad entry leaves every list unchanged.
+func (reg *Registry) Load(params []config.Parameter) error {
+	values := make(map[string]string)
+	for _, p := range params {
+		if strings.HasPrefix(p.Name, ParamPrefix) {
+			values[strings.TrimPrefix(p.Name, ParamPrefix)] = p.Value
+		}
+	}
+
+	type update struct {
+		list        *List
+		allow, deny []string
+	}
+	var updates []update
+	for name, list := range reg.lists {
+		allow, deny := reg.cfg.Lists[name].Allow, reg.cfg.Lists[name].Deny
+		if v, ok := values[name+".allow"]; ok {
+			allow = splitCIDRs(v)
+		}
+		if v, ok := values[name+".deny"]; ok {
+			deny = splitCIDRs(v)
+		}
+		if _, err := parseAll(append(slices.Clone(allow), deny...)); err != nil {
+			return fmt.Errorf("access list %s: %w", name, err)
+		}
+		updates = append(updates, update{list, allow, deny})
+	}
+	for _, u := range updates {
+		if err := u.list.set(u.allow, u.deny); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start reloads the params of the JSON config file whenever it changes,
+// until ctx is done
+func (reg *Registry) Start(ctx context.Context) {
+	if reg.path == "" || len(reg.lists) == 0 {
+		return
+	}
+	ticker := time.NewTicker(reg.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := reg.reload(); err != nil {
+				logger.Log.Error("Failed to reload access lists, keeping the current rules", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reload reads the JSON config file again when its modification time changed
+func (reg *Registry) reload() error {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	info, err := os.Stat(reg.path)
+	if err != nil {
+		return err
+	}
+	if info.ModTime().Equal(reg.modTime) {
+		return nil
+	}
+	jsonConfig, err := config.ReadJSONConfig(reg.path)
+	if err != nil {
+		return err
+	}
+	if err := reg.Load(jsonConfig.Params); err != nil {
+		return err
+	}
+	reg.modTime = info.ModTime()
+	for _, name := range reg.Names() {
+		allow, deny := reg.lists[name].Rules()
+		logger.Log.Info("Access list reloaded", zap.String("list", name), zap.Strings("allow", allow), zap.Strings("deny", deny))
+	}
+	return nil
+}
+
+func splitCIDRs(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func parseAll(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		prefix, err := config.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry %q: %w", entry, err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+func contains(prefixes []netip.Prefix, ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func prefixStrings(prefixes []netip.Prefix) []string {
+	out := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		out[i] = p.String()
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package accesslist
+
+import (
+	"net/netip"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListChecksDenyBeforeAllow(t *testing.T) {
+	reg, err := New(config.AccessListsConfig{Lists: map[string]config.AccessListConfig{
+		"partners": {Allow: []string{"203.0.113.0/24"}, Deny: []string{"203.0.113.66"}},
+	}}, nil, "")
+	require.NoError(t, err)
+	list, ok := reg.Get("partners")
+	require.True(t, ok)
+
+	for ip, want := range map[string]string{"203.0.113.5": "", "203.0.113.66": ReasonDenied, "198.51.100.1": ReasonNotAllowed} {
+		allowed, reason := list.Check(netip.MustParseAddr(ip))
+		assert.Equal(t, want == "", allowed, ip)
+		assert.Equal(t, want, reason, ip)
+	}
+	allowed, _ := list.Check(netip.Addr{})
+	assert.False(t, allowed, "unresolved clients must not pass an allow list")
+}
+
+func TestParamsReplaceYAMLRules(t *testing.T) {
+	cfg := config.AccessListsConfig{Lists: map[string]config.AccessListConfig{"admin": {Allow: []string{"10.0.0.0/8"}}}}
+	reg, err := New(cfg, []config.Parameter{{Name: "access_list.admin.allow", Value: "192.168.0.0/16, 127.0.0.1"}}, "")
+	require.NoError(t, err)
+	list, _ := reg.Get("admin")
+	allow, _ := list.Rules()
+	assert.Equal(t, []string{"192.168.0.0/16", "127.0.0.1/32"}, allow)
+
+	err = reg.Load([]config.Parameter{{Name: "access_list.admin.deny", Value: "not-an-ip"}})
+	require.Error(t, err)
+	allow, _ = list.Rules()
+	assert.Equal(t, []string{"192.168.0.0/16", "127.0.0.1/32"}, allow, "invalid params must keep the current rules")
+}
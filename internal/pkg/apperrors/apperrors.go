@@ -0,0 +1,102 @@
+// Package apperrors defines the typed application errors. Every error has a
+// stable code from the catalog, the HTTP status it maps to, a public message
+// that is safe to return to clients and an internal cause that is only
+// logged.
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code is a stable, machine-readable error identifier such as USER_EMAIL_TAKEN
+type Code string
+
+// Error is an application error
+type Error struct {
+	Code   Code
+	Status int
+	Title  string
+	// Message is the public, client-safe description
+	Message string
+	// Key identifies the message in the i18n bundles; it defaults to Code
+	Key string
+	// Cause is the internal error; it is logged but never sent to clients
+	Cause error
+}
+
+// New creates the error registered in the catalog under code. Unknown codes
+// produce an internal error so nothing unexpected reaches the client.
+func New(code Code, cause error) *Error {
+	def, ok := Lookup(code)
+	if !ok {
+		def, _ = Lookup(CodeInternal)
+	}
+	return &Error{
+		Code:    def.Code,
+		Status:  def.Status,
+		Title:   def.Title,
+		Message: def.Message,
+		Key:     string(def.Code),
+		Cause:   cause,
+	}
+}
+
+// WithMessage returns a copy of e with a more specific public message. The
+// message may be an i18n key or literal text; only pass text that is safe to
+// show to clients.
+func (e *Error) WithMessage(message string) *Error {
+	c := *e
+	c.Message = message
+	c.Key = message
+	return &c
+}
+
+// Error implements the error interface, including the internal cause
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the internal cause
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// From returns err as an *Error. Errors that are not application errors
+// become INTERNAL_ERROR with err as the cause.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return New(CodeInternal, err)
+}
+
+// Mapper maps domain errors to catalog codes. Each handler keeps its own
+// mapper for the errors of its domain.
+type Mapper map[error]Code
+
+// Map returns the application error for err. Application errors pass
+// through, domain errors are matched with errors.Is, and anything else is an
+// internal error.
+func (m Mapper) Map(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	for target, code := range m {
+		if errors.Is(err, target) {
+			return New(code, err)
+		}
+	}
+	return New(CodeInternal, err)
+}
+
+// IsServerError reports whether the error maps to a 5xx status
+func (e *Error) IsServerError() bool {
+	return e.Status >= http.StatusInternalServerError
+}
@@ -0,0 +1,119 @@
+package apperrors
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Generic codes
+const (
+	CodeBadRequest          Code = "BAD_REQUEST"
+	CodeInvalidRequestBody  Code = "INVALID_REQUEST_BODY"
+	CodeInvalidPage         Code = "INVALID_PAGE"
+	CodeInvalidLimit        Code = "INVALID_LIMIT"
+	CodeInvalidFilter       Code = "INVALID_FILTER"
+	CodeInvalidSort         Code = "INVALID_SORT"
+	CodeInvalidCursor       Code = "INVALID_CURSOR"
+	CodeUnauthorized        Code = "UNAUTHORIZED"
+	CodeInvalidToken        Code = "INVALID_TOKEN"
+	CodeTokenExpired        Code = "TOKEN_EXPIRED"
+	CodeForbidden           Code = "FORBIDDEN"
+	CodeNotFound            Code = "NOT_FOUND"
+	CodeConflict            Code = "CONFLICT"
+	CodePreconditionFailed  Code = "PRECONDITION_FAILED"
+	CodePayloadTooLarge     Code = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMedia    Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeUnprocessableEntity Code = "UNPROCESSABLE_ENTITY"
+	CodeTooManyRequests     Code = "TOO_MANY_REQUESTS"
+	CodeInternal            Code = "INTERNAL_ERROR"
+	CodeBadGateway          Code = "BAD_GATEWAY"
+	CodeServiceUnavailable  Code = "SERVICE_UNAVAILABLE"
+	CodeGatewayTimeout      Code = "GATEWAY_TIMEOUT"
+	CodeUnsupportedVersion  Code = "UNSUPPORTED_API_VERSION"
+)
+
+// User codes
+const (
+	CodeUserNotFound   Code = "USER_NOT_FOUND"
+	CodeUserInvalidID  Code = "USER_INVALID_ID"
+	CodeUserEmailTaken Code = "USER_EMAIL_TAKEN"
+	CodeUserInvalid    Code = "USER_INVALID"
+)
+
+// Definition is a catalog entry
+type Definition struct {
+	Code    Code
+	Status  int
+	Title   string
+	Message string
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = map[Code]Definition{}
+)
+
+func init() {
+	for _, def := range []Definition{
+		{CodeBadRequest, http.StatusBadRequest, "Bad Request", "The request is invalid"},
+		{CodeInvalidRequestBody, http.StatusBadRequest, "Invalid Request Body", "The request body could not be parsed"},
+		{CodeInvalidPage, http.StatusBadRequest, "Invalid Page", "Invalid page parameter"},
+		{CodeInvalidLimit, http.StatusBadRequest, "Invalid Limit", "Invalid limit parameter"},
+		{CodeInvalidFilter, http.StatusBadRequest, "Invalid Filter", "Invalid filter parameter"},
+		{CodeInvalidSort, http.StatusBadRequest, "Invalid Sort", "Invalid sort parameter"},
+		{CodeInvalidCursor, http.StatusBadRequest, "Invalid Cursor", "The cursor is not valid for this query"},
+		{CodeUnauthorized, http.StatusUnauthorized, "Unauthorized", "Authentication is required"},
+		{CodeInvalidToken, http.StatusUnauthorized, "Invalid Token", "The access token is not valid"},
+		{CodeTokenExpired, http.StatusUnauthorized, "Token Expired", "The access token has expired"},
+		{CodeForbidden, http.StatusForbidden, "Forbidden", "You are not allowed to perform this operation"},
+		{CodeNotFound, http.StatusNotFound, "Not Found", "The requested resource does not exist"},
+		{CodeConflict, http.StatusConflict, "Conflict", "The request conflicts with the current state of the resource"},
+		{CodePreconditionFailed, http.StatusPreconditionFailed, "Precondition Failed", "Precondition failed: resource has been modified"},
+		{CodePayloadTooLarge, http.StatusRequestEntityTooLarge, "Payload Too Large", "The request body is too large"},
+		{CodeUnsupportedMedia, http.StatusUnsupportedMediaType, "Unsupported Media Type", "The request body media type is not supported"},
+		{CodeUnprocessableEntity, http.StatusUnprocessableEntity, "Unprocessable Entity", "The request cannot be processed"},
+		{CodeTooManyRequests, http.StatusTooManyRequests, "Too Many Requests", "Too many requests, try again later"},
+		{CodeInternal, http.StatusInternalServerError, "Internal Server Error", "An unexpected error occurred"},
+		{CodeBadGateway, http.StatusBadGateway, "Bad Gateway", "An upstream service failed to respond correctly"},
+		{CodeServiceUnavailable, http.StatusServiceUnavailable, "Service Unavailable", "The service is temporarily unavailable, try again later"},
+		{CodeGatewayTimeout, http.StatusGatewayTimeout, "Gateway Timeout", "The request took too long to complete"},
+		{CodeUnsupportedVersion, http.StatusBadRequest, "Unsupported API Version", "The requested API version is not supported"},
+
+		{CodeUserNotFound, http.StatusNotFound, "User Not Found", "User not found"},
+		{CodeUserInvalidID, http.StatusBadRequest, "Invalid User ID", "The user ID is not valid"},
+		{CodeUserEmailTaken, http.StatusConflict, "Email Already Registered", "A user with this email already exists"},
+		{CodeUserInvalid, http.StatusBadRequest, "Invalid User", "The user data is not valid"},
+	} {
+		Register(def)
+	}
+}
+
+// Register adds or replaces a catalog entry, so modules can contribute the
+// codes of their own domain. Localized messages are looked up in the i18n
+// bundles by code; Message is used when a bundle has no entry.
+func Register(def Definition) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	catalog[def.Code] = def
+}
+
+// Lookup returns the catalog entry of code
+func Lookup(code Code) (Definition, bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	def, ok := catalog[code]
+	return def, ok
+}
+
+// Catalog returns every registered entry sorted by code
+func Catalog() []Definition {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	defs := make([]Definition, 0, len(catalog))
+	for _, def := range catalog {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Code < defs[j].Code })
+	return defs
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifierRoundTrip(t *testing.T) {
+	v := NewVerifier("secret")
+	token, err := v.Sign(Claims{Subject: "user-1", Scope: "users:read users:write", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+
+	claims, err := v.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.True(t, claims.HasPermission("users:write"))
+	assert.False(t, claims.HasPermission("users:delete"))
+}
+
+func TestVerifierRejectsBadTokens(t *testing.T) {
+	v := NewVerifier("secret")
+	expired, err := v.Sign(Claims{Subject: "user-1", ExpiresAt: time.Now().Add(-time.Hour).Unix()})
+	require.NoError(t, err)
+	_, err = v.Verify(expired)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+
+	other, err := NewVerifier("other").Sign(Claims{Subject: "user-1"})
+	require.NoError(t, err)
+	_, err = v.Verify(other)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	parts := strings.Split(other, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+	_, err = v.Verify(none)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
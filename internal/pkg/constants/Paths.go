@@ -3,10 +3,24 @@ package constants
 const (
 	UTILS_GROUP  = ""
 	HEALTH_CHECK = "/health"
+	LIVENESS     = HEALTH_CHECK + "/live"
+	READINESS    = HEALTH_CHECK + "/ready"
 	RSYNC        = "/rsync"
+	OPENAPI      = "/openapi.json"
+	DOCS         = "/docs"
 
 	USER_GROUP = "/users"
 	PREFIX     = USER_GROUP + "/examples/"
 
 	REST_CLIENT_GROUP = "/examples/dragonball"
+
+	ADMIN_GROUP = "/admin"
+	FEATURES    = "/features"
+	RUNTIME     = "/runtime"
+	BUILD_INFO  = "/buildinfo"
+	GOROUTINES  = "/goroutines"
+	HEAP_DUMP   = "/heapdump"
+	GC          = "/gc"
+	ROUTES      = "/routes"
+	PPROF_GROUP = "/debug/pprof"
 )
@@ -0,0 +1,205 @@
+// Package container is a typed dependency container. Providers are registered
+// per type, optionally under a name, and build lazy singletons the first time
+// they are resolved. Missing providers and dependency cycles are reported as
+// errors, and the edges followed while resolving form the dependency graph.
+package container
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type key struct {
+	typ  reflect.Type
+	name string
+}
+
+func (k key) String() string {
+	if k.name == "" {
+		return k.typ.String()
+	}
+	return k.typ.String() + "#" + k.name
+}
+
+type provider struct {
+	mu       sync.Mutex
+	build    func(c *Container) (any, error)
+	instance any
+	built    bool
+}
+
+type registry struct {
+	mu        sync.RWMutex
+	providers map[key]*provider
+	order     []key
+	edges     map[key]map[key]struct{}
+}
+
+// Container resolves dependencies. The container handed to a provider
+// carries the resolution path, which is how cycles are detected.
+type Container struct {
+	reg  *registry
+	path []key
+}
+
+// Option configures a provider
+type Option func(*key)
+
+// Named registers or resolves the provider under name, so several instances
+// of the same type can coexist
+func Named(name string) Option {
+	return func(k *key) { k.name = name }
+}
+
+// New creates an empty container
+func New() *Container {
+	return &Container{reg: &registry{
+		providers: make(map[key]*provider),
+		edges:     make(map[key]map[key]struct{}),
+	}}
+}
+
+// Provide registers the provider of T. It panics if T (with the same name)
+// already has a provider; use Override to replace one.
+func Provide[T any](c *Container, build func(c *Container) (T, error), opts ...Option) {
+	k := keyOf[T](opts)
+	c.reg.mu.Lock()
+	defer c.reg.mu.Unlock()
+	if _, exists := c.reg.providers[k]; exists {
+		panic(fmt.Sprintf("container: provider for %s registered twice", k))
+	}
+	c.reg.providers[k] = newProvider(build)
+	c.reg.order = append(c.reg.order, k)
+}
+
+// Value registers an already built instance of T
+func Value[T any](c *Container, v T, opts ...Option) {
+	Provide(c, func(*Container) (T, error) { return v, nil }, opts...)
+}
+
+// Override replaces the provider of T, discarding any instance already built.
+// It is meant for tests that swap real dependencies for fakes.
+func Override[T any](c *Container, build func(c *Container) (T, error), opts ...Option) {
+	k := keyOf[T](opts)
+	c.reg.mu.Lock()
+	defer c.reg.mu.Unlock()
+	if _, exists := c.reg.providers[k]; !exists {
+		c.reg.order = append(c.reg.order, k)
+	}
+	c.reg.providers[k] = newProvider(build)
+}
+
+// Resolve returns the singleton of T, building it and its dependencies on
+// first use. A failed build is not cached, so it is retried on the next call.
+func Resolve[T any](c *Container, opts ...Option) (T, error) {
+	var zero T
+	v, err := c.resolve(keyOf[T](opts))
+	if err != nil {
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// MustResolve is like Resolve but panics on error. Use it where a missing
+// dependency is a programming error, such as module initialization after
+// Validate succeeded.
+func MustResolve[T any](c *Container, opts ...Option) T {
+	v, err := Resolve[T](c, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Validate resolves every registered provider, so missing dependencies and
+// cycles fail at startup instead of on the first request
+func (c *Container) Validate() error {
+	c.reg.mu.RLock()
+	keys := append([]key(nil), c.reg.order...)
+	c.reg.mu.RUnlock()
+
+	for _, k := range keys {
+		if _, err := c.resolve(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Graph returns, for every provider, the dependencies it resolved while
+// being built
+func (c *Container) Graph() map[string][]string {
+	c.reg.mu.RLock()
+	defer c.reg.mu.RUnlock()
+
+	graph := make(map[string][]string, len(c.reg.order))
+	for _, k := range c.reg.order {
+		deps := make([]string, 0, len(c.reg.edges[k]))
+		for d := range c.reg.edges[k] {
+			deps = append(deps, d.String())
+		}
+		sort.Strings(deps)
+		graph[k.String()] = deps
+	}
+	return graph
+}
+
+func (c *Container) resolve(k key) (any, error) {
+	for i, p := range c.path {
+		if p == k {
+			cycle := make([]string, 0, len(c.path)-i+1)
+			for _, step := range c.path[i:] {
+				cycle = append(cycle, step.String())
+			}
+			cycle = append(cycle, k.String())
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	c.reg.mu.Lock()
+	p, ok := c.reg.providers[k]
+	if len(c.path) > 0 {
+		parent := c.path[len(c.path)-1]
+		if c.reg.edges[parent] == nil {
+			c.reg.edges[parent] = make(map[key]struct{})
+		}
+		c.reg.edges[parent][k] = struct{}{}
+	}
+	c.reg.mu.Unlock()
+
+	if !ok {
+		if len(c.path) > 0 {
+			return nil, fmt.Errorf("missing dependency %s required by %s", k, c.path[len(c.path)-1])
+		}
+		return nil, fmt.Errorf("missing dependency %s", k)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.built {
+		return p.instance, nil
+	}
+
+	child := &Container{reg: c.reg, path: append(append([]key(nil), c.path...), k)}
+	v, err := p.build(child)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s: %w", k, err)
+	}
+	p.instance, p.built = v, true
+	return v, nil
+}
+
+func newProvider[T any](build func(c *Container) (T, error)) *provider {
+	return &provider{build: func(c *Container) (any, error) { return build(c) }}
+}
+
+func keyOf[T any](opts []Option) key {
+	k := key{typ: reflect.TypeOf((*T)(nil)).Elem()}
+	for _, opt := range opts {
+		opt(&k)
+	}
+	return k
+}
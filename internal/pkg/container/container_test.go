@@ -0,0 +1,105 @@
+package container
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repo struct{ name string }
+
+type service struct{ repo *repo }
+
+func TestResolveBuildsLazySingletons(t *testing.T) {
+	c := New()
+	builds := 0
+	Provide(c, func(*Container) (*repo, error) {
+		builds++
+		return &repo{name: "mongo"}, nil
+	})
+	Provide(c, func(c *Container) (*service, error) {
+		r, err := Resolve[*repo](c)
+		return &service{repo: r}, err
+	})
+
+	assert.Equal(t, 0, builds, "providers must not run until resolved")
+
+	s1 := MustResolve[*service](c)
+	s2 := MustResolve[*service](c)
+	assert.Same(t, s1, s2)
+	assert.Equal(t, 1, builds)
+	assert.Equal(t, map[string][]string{
+		"*container.repo":    {},
+		"*container.service": {"*container.repo"},
+	}, c.Graph())
+}
+
+func TestNamedProviders(t *testing.T) {
+	c := New()
+	Value(c, &repo{name: "primary"})
+	Value(c, &repo{name: "replica"}, Named("replica"))
+
+	assert.Equal(t, "primary", MustResolve[*repo](c).name)
+	assert.Equal(t, "replica", MustResolve[*repo](c, Named("replica")).name)
+}
+
+func TestOverrideReplacesProvider(t *testing.T) {
+	c := New()
+	Value(c, &repo{name: "mongo"})
+	Provide(c, func(c *Container) (*service, error) {
+		r, err := Resolve[*repo](c)
+		return &service{repo: r}, err
+	})
+	Override(c, func(*Container) (*repo, error) { return &repo{name: "fake"}, nil })
+
+	assert.Equal(t, "fake", MustResolve[*service](c).repo.name)
+}
+
+func TestValidateReportsMissingDependency(t *testing.T) {
+	c := New()
+	Provide(c, func(c *Container) (*service, error) {
+		r, err := Resolve[*repo](c)
+		return &service{repo: r}, err
+	})
+
+	err := c.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing dependency *container.repo required by *container.service")
+}
+
+func TestValidateReportsCycle(t *testing.T) {
+	c := New()
+	Provide(c, func(c *Container) (*repo, error) {
+		_, err := Resolve[*service](c)
+		return &repo{}, err
+	})
+	Provide(c, func(c *Container) (*service, error) {
+		r, err := Resolve[*repo](c)
+		return &service{repo: r}, err
+	})
+
+	err := c.Validate()
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "dependency cycle: *container.repo -> *container.service -> *container.repo"), err.Error())
+}
+
+func TestFailedBuildIsRetried(t *testing.T) {
+	c := New()
+	fail := true
+	Provide(c, func(*Container) (*repo, error) {
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return &repo{}, nil
+	})
+
+	_, err := Resolve[*repo](c)
+	require.Error(t, err)
+
+	fail = false
+	_, err = Resolve[*repo](c)
+	assert.NoError(t, err)
+}
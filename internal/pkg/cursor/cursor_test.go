@@ -0,0 +1,62 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	payload := []byte(`{"id":42}`)
+
+	token := s.Sign(payload)
+	got, err := s.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+
+	again, err := NewSigner([]byte("secret")).Verify(token)
+	require.NoError(t, err, "the same key verifies across signers")
+	assert.Equal(t, payload, again)
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	token := s.Sign([]byte(`{"id":42}`))
+	data, sig, _ := strings.Cut(token, ".")
+	enc := base64.RawURLEncoding
+
+	forged := enc.EncodeToString([]byte(`{"id":43}`))
+	otherSig := strings.SplitN(s.Sign([]byte(`{"id":43}`)), ".", 2)[1]
+	tests := map[string]string{
+		"TamperedPayload":   forged + "." + sig,
+		"SwappedSignature":  data + "." + otherSig,
+		"TruncatedSig":      data + "." + sig[:len(sig)-2],
+		"EmptySignature":    data + ".",
+		"MissingSeparator":  data + sig,
+		"InvalidBase64":     "!!!." + sig,
+		"InvalidSigBase64":  data + ".!!!",
+		"Empty":             "",
+		"OtherKey":          NewSigner([]byte("other")).Sign([]byte(`{"id":42}`)),
+		"RandomKeyInstance": NewSigner(nil).Sign([]byte(`{"id":42}`)),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.Verify(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+func TestNewSignerWithoutKeyIsRandom(t *testing.T) {
+	a, b := NewSigner(nil), NewSigner(nil)
+	token := a.Sign([]byte("x"))
+
+	_, err := a.Verify(token)
+	require.NoError(t, err)
+	_, err = b.Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,316 @@
+// Package features provides feature flags with percentage rollouts and
+// per-request evaluation
+package features
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+)
+
+// Built-in flags declared under app.features in the YAML configuration
+const (
+	MaintenanceMode = "maintenance_mode"
+	EnableMetrics   = "enable_metrics"
+	EnableTracing   = "enable_tracing"
+)
+
+// ParamPrefix is the prefix of JSONConfig.Params entries that define flags,
+// e.g. {"name": "feature.new_checkout", "value": "25%"}
+const ParamPrefix = "feature."
+
+// Flag sources, from lowest to highest precedence
+const (
+	SourceConfig = "config"
+	SourceParams = "params"
+	SourceAdmin  = "admin"
+)
+
+// Flag is a feature flag definition
+type Flag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Percentage of subjects (user or tenant) the flag is rolled out to, 0-100
+	Percentage int `json:"percentage"`
+	// Users and Tenants always get the flag when it is enabled
+	Users   []string `json:"users,omitempty"`
+	Tenants []string `json:"tenants,omitempty"`
+	// AllowHeaderOverride lets callers force the flag with the X-Feature-Flags header
+	AllowHeaderOverride bool   `json:"allowHeaderOverride"`
+	Source              string `json:"source"`
+}
+
+// EvalContext holds the request attributes flags are evaluated against
+type EvalContext struct {
+	UserID    string
+	TenantID  string
+	Overrides map[string]bool
+}
+
+type contextKey struct{}
+
+// WithEvalContext stores the evaluation context in ctx
+func WithEvalContext(ctx context.Context, ec EvalContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, ec)
+}
+
+// EvalContextFrom retrieves the evaluation context from ctx
+func EvalContextFrom(ctx context.Context) EvalContext {
+	if ctx == nil {
+		return EvalContext{}
+	}
+	ec, _ := ctx.Value(contextKey{}).(EvalContext)
+	return ec
+}
+
+// Store holds the flag definitions. Definitions from the admin API take
+// precedence over JSONConfig.Params, which take precedence over the YAML.
+type Store struct {
+	mu    sync.RWMutex
+	base  map[string]Flag // config and params
+	admin map[string]Flag // runtime overrides
+}
+
+// NewStore creates a store from the YAML features section and the params of
+// the JSON configuration (which may be nil)
+func NewStore(cfg config.FeaturesConfig, jsonConfig *config.JSONConfig) *Store {
+	s := &Store{
+		base:  make(map[string]Flag),
+		admin: make(map[string]Flag),
+	}
+
+	s.base[MaintenanceMode] = Flag{Name: MaintenanceMode, Enabled: cfg.MaintenanceMode, Percentage: 100, Source: SourceConfig}
+	s.base[EnableMetrics] = Flag{Name: EnableMetrics, Enabled: cfg.EnableMetrics, Percentage: 100, Source: SourceConfig}
+	s.base[EnableTracing] = Flag{Name: EnableTracing, Enabled: cfg.EnableTracing, Percentage: 100, Source: SourceConfig}
+
+	for name, fc := range cfg.Flags {
+		pct := 100
+		if fc.Percentage != nil {
+			pct = clampPercentage(*fc.Percentage)
+		}
+		s.base[name] = Flag{
+			Name:                name,
+			Enabled:             fc.Enabled,
+			Percentage:          pct,
+			Users:               fc.Users,
+			Tenants:             fc.Tenants,
+			AllowHeaderOverride: fc.AllowHeaderOverride,
+			Source:              SourceConfig,
+		}
+	}
+
+	if jsonConfig != nil {
+		s.LoadParams(jsonConfig.Params)
+	}
+
+	return s
+}
+
+// LoadParams applies flags defined in JSONConfig.Params. Values are "true",
+// "false" or a rollout percentage such as "25%".
+func (s *Store) LoadParams(params []config.Parameter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range params {
+		if !strings.HasPrefix(p.Name, ParamPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(p.Name, ParamPrefix)
+		flag, ok := s.base[name]
+		if !ok {
+			flag = Flag{Name: name}
+		}
+		enabled, pct, ok := parseParamValue(p.Value)
+		if !ok {
+			continue
+		}
+		flag.Enabled = enabled
+		flag.Percentage = pct
+		flag.Source = SourceParams
+		s.base[name] = flag
+	}
+}
+
+// Set stores a runtime override, as done by the admin API
+func (s *Store) Set(flag Flag) {
+	flag.Percentage = clampPercentage(flag.Percentage)
+	flag.Source = SourceAdmin
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.admin[flag.Name] = flag
+}
+
+// Reset removes a runtime override and reports whether one existed
+func (s *Store) Reset(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.admin[name]
+	delete(s.admin, name)
+	return ok
+}
+
+// Get returns the effective definition of a flag
+func (s *Store) Get(name string) (Flag, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if f, ok := s.admin[name]; ok {
+		return f, true
+	}
+	f, ok := s.base[name]
+	return f, ok
+}
+
+// List returns the effective definitions of every flag sorted by name
+func (s *Store) List() []Flag {
+	s.mu.RLock()
+	names := make(map[string]struct{}, len(s.base)+len(s.admin))
+	for n := range s.base {
+		names[n] = struct{}{}
+	}
+	for n := range s.admin {
+		names[n] = struct{}{}
+	}
+	s.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(names))
+	for n := range names {
+		if f, ok := s.Get(n); ok {
+			flags = append(flags, f)
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// Enabled reports whether a flag is on for everyone, ignoring request attributes
+func (s *Store) Enabled(name string) bool {
+	if s == nil {
+		return false
+	}
+	f, ok := s.Get(name)
+	return ok && f.Enabled && f.Percentage >= 100
+}
+
+// IsEnabled evaluates a flag against the request attributes stored in ctx
+func (s *Store) IsEnabled(ctx context.Context, name string) bool {
+	return s.Evaluate(name, EvalContextFrom(ctx))
+}
+
+// Evaluate evaluates a flag for the given context
+func (s *Store) Evaluate(name string, ec EvalContext) bool {
+	if s == nil {
+		return false
+	}
+	f, ok := s.Get(name)
+	if !ok {
+		return false
+	}
+
+	if f.AllowHeaderOverride {
+		if v, ok := ec.Overrides[name]; ok {
+			return v
+		}
+	}
+	if !f.Enabled {
+		return false
+	}
+	if ec.UserID != "" && contains(f.Users, ec.UserID) {
+		return true
+	}
+	if ec.TenantID != "" && contains(f.Tenants, ec.TenantID) {
+		return true
+	}
+	if f.Percentage >= 100 {
+		return true
+	}
+	if f.Percentage <= 0 {
+		return false
+	}
+
+	// Stable bucketing: the same subject always lands in the same bucket
+	subject := ec.UserID
+	if subject == "" {
+		subject = ec.TenantID
+	}
+	if subject == "" {
+		return false
+	}
+	return bucket(name, subject) < f.Percentage
+}
+
+// ParseOverrides parses an X-Feature-Flags header value such as
+// "new_checkout=on,beta_search=off"
+func ParseOverrides(header string) map[string]bool {
+	if header == "" {
+		return nil
+	}
+	overrides := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		name, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if name == "" {
+			continue
+		}
+		if !found {
+			overrides[name] = true
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "on", "true", "1":
+			overrides[name] = true
+		case "off", "false", "0":
+			overrides[name] = false
+		}
+	}
+	return overrides
+}
+
+func parseParamValue(value string) (bool, int, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "%") {
+		pct, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil {
+			return false, 0, false
+		}
+		pct = clampPercentage(pct)
+		return pct > 0, pct, true
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, 0, false
+	}
+	return enabled, 100, true
+}
+
+func bucket(name, subject string) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{':'})
+	h.Write([]byte(subject))
+	return int(h.Sum32() % 100)
+}
+
+func clampPercentage(p int) int {
+	if p < 0 {
+		return 0
+	}
+	if p > 100 {
+		return 100
+	}
+	return p
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package features
+
+import (
+	"fmt"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestEvaluate(t *testing.T) {
+	store := NewStore(config.FeaturesConfig{Flags: map[string]config.FlagConfig{
+		"full":     {Enabled: true},
+		"none":     {Enabled: true, Percentage: intPtr(0)},
+		"half":     {Enabled: true, Percentage: intPtr(50)},
+		"off":      {Enabled: false},
+		"listed":   {Enabled: true, Percentage: intPtr(0), Users: []string{"u1"}, Tenants: []string{"t1"}},
+		"override": {Enabled: false, AllowHeaderOverride: true},
+	}}, nil)
+
+	tests := []struct {
+		name string
+		flag string
+		ec   EvalContext
+		want bool
+	}{
+		{"MissingFlag", "unknown", EvalContext{UserID: "u1"}, false},
+		{"Disabled", "off", EvalContext{UserID: "u1"}, false},
+		{"HundredPercent", "full", EvalContext{UserID: "u1"}, true},
+		{"HundredPercentWithoutSubject", "full", EvalContext{}, true},
+		{"ZeroPercent", "none", EvalContext{UserID: "u1"}, false},
+		{"PartialWithoutSubject", "half", EvalContext{}, false},
+		{"ListedUser", "listed", EvalContext{UserID: "u1"}, true},
+		{"ListedTenant", "listed", EvalContext{UserID: "u2", TenantID: "t1"}, true},
+		{"NotListed", "listed", EvalContext{UserID: "u2"}, false},
+		{"HeaderOverride", "override", EvalContext{Overrides: map[string]bool{"override": true}}, true},
+		{"HeaderOverrideNotAllowed", "off", EvalContext{UserID: "u1", Overrides: map[string]bool{"off": true}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, store.Evaluate(tt.flag, tt.ec))
+		})
+	}
+}
+
+func TestEvaluatePercentageRollout(t *testing.T) {
+	store := NewStore(config.FeaturesConfig{Flags: map[string]config.FlagConfig{
+		"half": {Enabled: true, Percentage: intPtr(50)},
+	}}, nil)
+
+	enabled := 0
+	for i := range 1000 {
+		ec := EvalContext{UserID: fmt.Sprintf("user-%d", i)}
+		first := store.Evaluate("half", ec)
+		// El mismo sujeto cae siempre en el mismo bucket
+		for range 3 {
+			assert.Equal(t, first, store.Evaluate("half", ec))
+		}
+		if first {
+			enabled++
+		}
+	}
+	assert.InDelta(t, 500, enabled, 75)
+
+	// Sin usuario se reparte por tenant
+	ec := EvalContext{TenantID: "tenant-1"}
+	assert.Equal(t, bucket("half", "tenant-1") < 50, store.Evaluate("half", ec))
+}
+
+func TestPercentageDefaults(t *testing.T) {
+	tests := []struct {
+		name       string
+		percentage *int
+		want       int
+	}{
+		{"Omitted", nil, 100},
+		{"Explicit", intPtr(25), 25},
+		{"Zero", intPtr(0), 0},
+		{"Clamped", intPtr(150), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore(config.FeaturesConfig{Flags: map[string]config.FlagConfig{
+				"flag": {Enabled: true, Percentage: tt.percentage},
+			}}, nil)
+			flag, ok := store.Get("flag")
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, flag.Percentage)
+		})
+	}
+}
+
+func TestLoadParams(t *testing.T) {
+	store := NewStore(config.FeaturesConfig{}, &config.JSONConfig{Params: []config.Parameter{
+		{Name: "feature.rollout", Value: "25%"},
+		{Name: "feature.on", Value: "true"},
+		{Name: "feature.broken", Value: "maybe"},
+		{Name: "other", Value: "true"},
+	}})
+
+	flag, ok := store.Get("rollout")
+	assert.True(t, ok)
+	assert.True(t, flag.Enabled)
+	assert.Equal(t, 25, flag.Percentage)
+	assert.Equal(t, SourceParams, flag.Source)
+
+	flag, ok = store.Get("on")
+	assert.True(t, ok)
+	assert.Equal(t, 100, flag.Percentage)
+
+	_, ok = store.Get("broken")
+	assert.False(t, ok)
+	_, ok = store.Get("other")
+	assert.False(t, ok)
+}
+
+func TestSetAndReset(t *testing.T) {
+	store := NewStore(config.FeaturesConfig{MaintenanceMode: false}, nil)
+
+	store.Set(Flag{Name: MaintenanceMode, Enabled: true, Percentage: 100})
+	assert.True(t, store.Enabled(MaintenanceMode))
+	flag, _ := store.Get(MaintenanceMode)
+	assert.Equal(t, SourceAdmin, flag.Source)
+
+	assert.True(t, store.Reset(MaintenanceMode))
+	assert.False(t, store.Enabled(MaintenanceMode))
+	assert.False(t, store.Reset(MaintenanceMode))
+}
+
+func TestParseOverrides(t *testing.T) {
+	assert.Nil(t, ParseOverrides(""))
+	assert.Equal(t, map[string]bool{"a": true, "b": false, "c": true},
+		ParseOverrides("a=on, b=off,c,d=unknown"))
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+)
+
+// JSONConfigChecker reports whether the JSON configuration has been loaded
+func JSONConfigChecker() Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		if config.GetJSONConfig() == nil {
+			return errors.New("JSON configuration is not loaded")
+		}
+		return nil
+	})
+}
+
+// IntegrationChecker verifies that the host of an integration accepts TCP
+// connections. The host and port are read from the integration paths
+// "<name>.domain" and "<name>.port" of the JSON configuration.
+func IntegrationChecker(name string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		host, ok := utils.GetIntegrationPath(name + ".domain")
+		if !ok || host == "" {
+			return fmt.Errorf("integration path %s.domain is not configured", name)
+		}
+		port, ok := utils.GetIntegrationPath(name + ".port")
+		if !ok || port == "" {
+			port = "443"
+		}
+
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
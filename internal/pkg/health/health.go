@@ -0,0 +1,170 @@
+// Package health provides liveness and readiness checks with per-component
+// checkers whose results are cached between probes
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check statuses
+const (
+	StatusUp      = "UP"
+	StatusDown    = "DOWN"
+	StatusUnknown = "UNKNOWN"
+)
+
+// Checker verifies a single dependency
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to the Checker interface
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx)
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result is the last known outcome of a check
+type Result struct {
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Critical    bool      `json:"critical"`
+	LatencyMs   int64     `json:"latencyMs"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastChecked time.Time `json:"lastChecked,omitempty"`
+	LastSuccess time.Time `json:"lastSuccess,omitempty"`
+}
+
+type entry struct {
+	checker Checker
+	mu      sync.Mutex
+	result  Result
+}
+
+// Registry holds the registered checkers. Critical checks decide readiness;
+// non-critical checks are reported but do not take the service out of rotation.
+type Registry struct {
+	mu       sync.RWMutex
+	entries  map[string]*entry
+	interval time.Duration
+	timeout  time.Duration
+	ready    atomic.Bool
+}
+
+// NewRegistry creates a registry that reuses results for interval and gives
+// each check at most timeout to complete
+func NewRegistry(interval, timeout time.Duration) *Registry {
+	r := &Registry{
+		entries:  make(map[string]*entry),
+		interval: interval,
+		timeout:  timeout,
+	}
+	r.ready.Store(true)
+	return r
+}
+
+// Register adds or replaces a checker
+func (r *Registry) Register(name string, critical bool, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[name] = &entry{
+		checker: checker,
+		result:  Result{Name: name, Status: StatusUnknown, Critical: critical},
+	}
+}
+
+// SetReady marks the service as accepting or refusing traffic regardless of
+// the checks, e.g. while draining during shutdown
+func (r *Registry) SetReady(ready bool) {
+	r.ready.Store(ready)
+}
+
+// Live reports whether the process is alive. It never runs dependency
+// checks, so a failing dependency does not get the process restarted.
+func (r *Registry) Live() bool {
+	return true
+}
+
+// Ready reports whether every critical check is up and the service has not
+// been marked as not ready, together with the check results
+func (r *Registry) Ready(ctx context.Context) (bool, []Result) {
+	results := r.Results(ctx)
+	if !r.ready.Load() {
+		return false, results
+	}
+	for _, res := range results {
+		if res.Critical && res.Status != StatusUp {
+			return false, results
+		}
+	}
+	return true, results
+}
+
+// Results runs the checks whose cached result is older than the check
+// interval, concurrently, and returns every result sorted by name
+func (r *Registry) Results(ctx context.Context) []Result {
+	r.mu.RLock()
+	entries := make([]*entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	r.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	results := make([]Result, len(entries))
+	for i, e := range entries {
+		wg.Add(1)
+		go func(i int, e *entry) {
+			defer wg.Done()
+			results[i] = r.run(ctx, e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
+
+// run executes a single check unless its cached result is still fresh.
+// Concurrent probes wait for the check in progress instead of repeating it.
+//
+// The result is shared by every probe, so the check only depends on its own
+// timeout: it does not see the caller's cancellation, and a failure seen
+// after the caller went away is returned but not cached.
+func (r *Registry) run(ctx context.Context, e *entry) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.result.LastChecked.IsZero() && time.Since(e.result.LastChecked) < r.interval {
+		return e.result
+	}
+
+	checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := e.checker.Check(checkCtx)
+
+	result := e.result
+	result.LatencyMs = time.Since(start).Milliseconds()
+	result.LastChecked = start
+	if err != nil {
+		result.Status = StatusDown
+		result.LastError = err.Error()
+		if ctx.Err() != nil {
+			return result
+		}
+	} else {
+		result.Status = StatusUp
+		result.LastError = ""
+		result.LastSuccess = start
+	}
+	e.result = result
+	return result
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingChecker cuenta sus ejecuciones y devuelve err
+type countingChecker struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (c *countingChecker) Check(ctx context.Context) error {
+	c.calls.Add(1)
+	return c.err
+}
+
+func TestResultsAreCached(t *testing.T) {
+	cached := &countingChecker{}
+	r := NewRegistry(time.Hour, time.Second)
+	r.Register("db", true, cached)
+
+	first := r.Results(context.Background())
+	second := r.Results(context.Background())
+
+	assert.Equal(t, int32(1), cached.calls.Load())
+	require.Len(t, first, 1)
+	assert.Equal(t, StatusUp, first[0].Status)
+	assert.Equal(t, first, second)
+
+	uncached := &countingChecker{}
+	r = NewRegistry(0, time.Second)
+	r.Register("db", true, uncached)
+	r.Results(context.Background())
+	r.Results(context.Background())
+	assert.Equal(t, int32(2), uncached.calls.Load())
+}
+
+func TestReadyAggregatesCriticalChecks(t *testing.T) {
+	failing := &countingChecker{err: errors.New("connection refused")}
+
+	tests := []struct {
+		name     string
+		critical bool
+		failing  bool
+		ready    bool
+		want     bool
+	}{
+		{"AllUp", true, false, true, true},
+		{"CriticalDown", true, true, true, false},
+		{"NonCriticalDown", false, true, true, true},
+		{"MarkedNotReady", true, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(0, time.Second)
+			r.Register("json", true, &countingChecker{})
+			if tt.failing {
+				r.Register("dep", tt.critical, failing)
+			} else {
+				r.Register("dep", tt.critical, &countingChecker{})
+			}
+			r.SetReady(tt.ready)
+
+			ready, results := r.Ready(context.Background())
+			assert.Equal(t, tt.want, ready)
+			require.Len(t, results, 2)
+			assert.Equal(t, "dep", results[0].Name)
+			assert.Equal(t, "json", results[1].Name)
+			if tt.failing {
+				assert.Equal(t, StatusDown, results[0].Status)
+				assert.Equal(t, "connection refused", results[0].LastError)
+			}
+		})
+	}
+}
+
+func TestCheckIgnoresCallerCancellation(t *testing.T) {
+	r := NewRegistry(time.Hour, time.Second)
+	r.Register("db", true, CheckerFunc(func(ctx context.Context) error {
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ready, results := r.Ready(ctx)
+
+	assert.True(t, ready)
+	assert.Equal(t, StatusUp, results[0].Status)
+}
+
+func TestFailureAfterCallerCancelledIsNotCached(t *testing.T) {
+	checker := &countingChecker{err: errors.New("interrupted")}
+	r := NewRegistry(time.Hour, time.Second)
+	r.Register("db", true, checker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := r.Results(ctx)
+	assert.Equal(t, StatusDown, results[0].Status)
+
+	checker.err = nil
+	results = r.Results(context.Background())
+	assert.Equal(t, int32(2), checker.calls.Load())
+	assert.Equal(t, StatusUp, results[0].Status)
+}
+
+func TestCheckTimeout(t *testing.T) {
+	r := NewRegistry(time.Hour, 10*time.Millisecond)
+	r.Register("slow", true, CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+
+	ready, results := r.Ready(context.Background())
+	assert.False(t, ready)
+	assert.Equal(t, StatusDown, results[0].Status)
+	assert.Contains(t, results[0].LastError, "deadline exceeded")
+}
@@ -0,0 +1,146 @@
+// Package i18n holds the localized response messages. Bundles are embedded
+// JSON files keyed by message code, one per language, and the language of a
+// request is negotiated from Accept-Language.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Fallback is the language used when neither the requested nor the default
+// language has a message
+const Fallback = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+var (
+	bundles     = map[string]map[string]string{}
+	defaultLang atomic.Value
+)
+
+type contextKey struct{}
+
+func init() {
+	files, err := locales.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+	for _, f := range files {
+		data, err := locales.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid bundle %s: %v", f.Name(), err))
+		}
+		bundles[strings.TrimSuffix(f.Name(), ".json")] = messages
+	}
+	defaultLang.Store(Fallback)
+}
+
+// SetDefault sets the language used when Accept-Language matches no bundle
+func SetDefault(lang string) error {
+	if _, ok := bundles[lang]; !ok {
+		return fmt.Errorf("unsupported language %q, available: %s", lang, strings.Join(Supported(), ", "))
+	}
+	defaultLang.Store(lang)
+	return nil
+}
+
+// Default returns the default language
+func Default() string {
+	return defaultLang.Load().(string)
+}
+
+// Supported returns the languages with a bundle, sorted
+func Supported() []string {
+	langs := make([]string, 0, len(bundles))
+	for lang := range bundles {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Match returns the supported language that best fits an Accept-Language
+// header, honoring quality values, or the default language
+func Match(acceptLanguage string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, q := parseLanguage(part)
+		if tag == "" || q <= bestQ {
+			continue
+		}
+		// Se compara solo el idioma principal: es-CO coincide con es
+		primary, _, _ := strings.Cut(tag, "-")
+		if _, ok := bundles[primary]; ok {
+			best, bestQ = primary, q
+		}
+	}
+	if best == "" {
+		return Default()
+	}
+	return best
+}
+
+// Translate returns the message for key in lang, falling back to the
+// default language and then to Fallback
+func Translate(lang, key string) (string, bool) {
+	for _, l := range []string{lang, Default(), Fallback} {
+		if msg, ok := bundles[l][key]; ok {
+			return msg, true
+		}
+	}
+	return "", false
+}
+
+// Message returns the message for key in lang, or key itself when it is not
+// a catalog key, so literal texts pass through unchanged
+func Message(lang, key string) string {
+	if msg, ok := Translate(lang, key); ok {
+		return msg
+	}
+	return key
+}
+
+// WithLanguage stores the negotiated language in the context
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language stored in the context or the default
+func FromContext(ctx context.Context) string {
+	if lang, ok := ctx.Value(contextKey{}).(string); ok && lang != "" {
+		return lang
+	}
+	return Default()
+}
+
+// parseLanguage splits an Accept-Language entry into its lowercase tag and
+// quality value
+func parseLanguage(part string) (string, float64) {
+	tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || tag == "*" {
+		return "", 0
+	}
+	q := 1.0
+	if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+	return tag, q
+}
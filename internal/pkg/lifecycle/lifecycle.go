@@ -0,0 +1,202 @@
+// Package lifecycle coordinates ordered startup and shutdown of the
+// application components
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// Standard hook orders. Hooks start in ascending order and stop in
+// descending order, so on shutdown readiness is withdrawn first, then the
+// servers drain, then background workers stop, and infrastructure such as
+// the database closes last.
+const (
+	OrderInfrastructure = 0
+	OrderWorkers        = 100
+	OrderServers        = 200
+	OrderReadiness      = 300
+)
+
+// Hook is a component taking part in the application lifecycle
+type Hook struct {
+	Name  string
+	Order int
+	// Timeout bounds Start and Stop individually; zero uses the manager default
+	Timeout time.Duration
+	// Start must not block; long-running work belongs in a goroutine
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Manager runs the registered hooks
+type Manager struct {
+	mu             sync.Mutex
+	hooks          []Hook
+	started        []Hook
+	defaultTimeout time.Duration
+	fatal          chan error
+}
+
+// NewManager creates a manager whose hooks get defaultTimeout each unless
+// they set their own
+func NewManager(defaultTimeout time.Duration) *Manager {
+	return &Manager{
+		defaultTimeout: defaultTimeout,
+		fatal:          make(chan error, 1),
+	}
+}
+
+// Register adds a hook. Hooks with the same order keep registration order.
+func (m *Manager) Register(h Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, h)
+}
+
+// Fail reports an unrecoverable runtime error, such as a listener dying,
+// and triggers the shutdown of a running manager
+func (m *Manager) Fail(err error) {
+	select {
+	case m.fatal <- err:
+	default:
+	}
+}
+
+// Start runs the Start function of every hook in ascending order. If one
+// fails, the hooks already started are stopped and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Order < hooks[j].Order })
+
+	for _, h := range hooks {
+		if h.Start != nil {
+			if err := m.call(ctx, h, "start", h.Start); err != nil {
+				_ = m.Stop(context.WithoutCancel(ctx))
+				return fmt.Errorf("failed to start %s: %w", h.Name, err)
+			}
+		}
+		m.mu.Lock()
+		m.started = append(m.started, h)
+		m.mu.Unlock()
+	}
+	return nil
+}
+
+// Stop runs the Stop function of every started hook in reverse order. Each
+// hook is bounded by its own timeout and by ctx; every hook is attempted even
+// if an earlier one fails.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	started := m.started
+	m.started = nil
+	m.mu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		h := started[i]
+		if h.Stop == nil {
+			continue
+		}
+		if err := m.call(ctx, h, "stop", h.Stop); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", h.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run starts every hook, waits until ctx is cancelled or a component fails,
+// and then stops every hook within shutdownTimeout
+func (m *Manager) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	if err := m.Start(ctx); err != nil {
+		return err
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		logger.Log.Info("Shutdown requested, stopping components...")
+	case runErr = <-m.fatal:
+		logger.Log.Error("Component failed, stopping components...", zap.Error(runErr))
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := m.Stop(stopCtx); err != nil {
+		return errors.Join(runErr, err)
+	}
+	if errors.Is(stopCtx.Err(), context.DeadlineExceeded) {
+		return errors.Join(runErr, errors.New("graceful shutdown timed out"))
+	}
+	logger.Log.Info("All components stopped")
+	return runErr
+}
+
+// ServerHook returns a hook that binds srv on start and drains in-flight
+// requests with Shutdown on stop. The listener is opened synchronously so
+// bind errors fail startup; serving errors are reported through Fail.
+func (m *Manager) ServerHook(name string, order int, srv *http.Server) Hook {
+	return Hook{
+		Name:  name,
+		Order: order,
+		Start: func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", srv.Addr)
+			if err != nil {
+				return err
+			}
+			logger.Log.Info("Listening", zap.String("server", name), zap.String("addr", srv.Addr), zap.Bool("tls", srv.TLSConfig != nil))
+			go func() {
+				var err error
+				if srv.TLSConfig != nil {
+					// Certificates are provided by srv.TLSConfig.GetCertificate
+					err = srv.ServeTLS(ln, "", "")
+				} else {
+					err = srv.Serve(ln)
+				}
+				if err != nil && !errors.Is(err, http.ErrServerClosed) {
+					m.Fail(fmt.Errorf("%s: %w", name, err))
+				}
+			}()
+			return nil
+		},
+		Stop: srv.Shutdown,
+	}
+}
+
+// call runs fn with the hook timeout applied on top of ctx
+func (m *Manager) call(ctx context.Context, h Hook, phase string, fn func(context.Context) error) error {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = m.defaultTimeout
+	}
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(hookCtx)
+	log := logger.Log.With(
+		zap.String("component", h.Name),
+		zap.String("phase", phase),
+		zap.Duration("duration", time.Since(start)),
+	)
+	if err != nil {
+		log.Error("Lifecycle hook failed", zap.Error(err))
+		return err
+	}
+	log.Info("Lifecycle hook completed")
+	return nil
+}
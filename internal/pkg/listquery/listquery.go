@@ -0,0 +1,195 @@
+// Package listquery parses the query contract of list endpoints: equality
+// and range filters (filter[email]=..., filter[date_created][gte]=...) and
+// multi-field sorting (sort=-date_created,email). Fields are checked against
+// the whitelist of the resource so clients can only filter and sort by
+// exposed, indexed fields.
+package listquery
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Errores de los parámetros de consulta
+var (
+	ErrInvalidFilter = errors.New("invalid filter parameter")
+	ErrInvalidSort   = errors.New("invalid sort parameter")
+	ErrInvalidPage   = errors.New("invalid page parameter")
+)
+
+// Kind is the type of the values of a field
+type Kind int
+
+const (
+	// String fields only support equality
+	String Kind = iota
+	// Time fields support equality and ranges; values are RFC 3339 timestamps
+	// or dates (2006-01-02)
+	Time
+)
+
+// Operators of a filter
+const (
+	OpEq  = "eq"
+	OpGt  = "gt"
+	OpGte = "gte"
+	OpLt  = "lt"
+	OpLte = "lte"
+)
+
+// Field describes a field clients may use
+type Field struct {
+	// Name is the name in the query string
+	Name string
+	// Column is the name in the store; Name when empty
+	Column string
+	Kind   Kind
+	// Filter and Sort enable the field for each use
+	Filter bool
+	Sort   bool
+}
+
+// Schema is the whitelist of a resource
+type Schema struct {
+	Fields []Field
+	// DefaultSort applies when the request has no sort
+	DefaultSort []Sort
+}
+
+// Filter is a condition on a column
+type Filter struct {
+	Column string
+	Op     string
+	Value  any
+}
+
+// Sort orders by a column
+type Sort struct {
+	Column string
+	Desc   bool
+}
+
+// Query holds the filters and sort of a list request
+type Query struct {
+	Filters []Filter
+	Sort    []Sort
+}
+
+// Parse reads the filters and sort of values. Unknown fields, unsupported
+// operators and malformed values are rejected with ErrInvalidFilter or
+// ErrInvalidSort; parameters outside filter[...] and sort are ignored.
+func Parse(values url.Values, schema Schema) (Query, error) {
+	fields := make(map[string]Field, len(schema.Fields))
+	for _, f := range schema.Fields {
+		if f.Column == "" {
+			f.Column = f.Name
+		}
+		fields[f.Name] = f
+	}
+
+	var q Query
+	for key, vals := range values {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+		name, op, err := filterKey(key)
+		if err != nil {
+			return Query{}, err
+		}
+		field, ok := fields[name]
+		if !ok || !field.Filter {
+			return Query{}, fmt.Errorf("%w: unknown field %q", ErrInvalidFilter, name)
+		}
+		for _, raw := range vals {
+			filter, err := parseFilter(field, op, raw)
+			if err != nil {
+				return Query{}, err
+			}
+			q.Filters = append(q.Filters, filter)
+		}
+	}
+
+	sort := strings.TrimSpace(values.Get("sort"))
+	if sort == "" {
+		q.Sort = append(q.Sort, schema.DefaultSort...)
+		return q, nil
+	}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		name := strings.TrimPrefix(part, "-")
+		field, ok := fields[name]
+		if !ok || !field.Sort {
+			return Query{}, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, name)
+		}
+		if seen[name] {
+			return Query{}, fmt.Errorf("%w: field %q is repeated", ErrInvalidSort, name)
+		}
+		seen[name] = true
+		q.Sort = append(q.Sort, Sort{Column: field.Column, Desc: desc})
+	}
+	return q, nil
+}
+
+// Offset returns the number of documents before page with pages of limit
+// documents. Pages whose offset does not fit in an int64 are rejected with
+// ErrInvalidPage instead of wrapping around to a negative skip.
+func Offset(page, limit int64) (int64, error) {
+	if page < 1 || limit < 1 {
+		return 0, fmt.Errorf("%w: page %d with limit %d", ErrInvalidPage, page, limit)
+	}
+	if page-1 > math.MaxInt64/limit {
+		return 0, fmt.Errorf("%w: page %d is out of range", ErrInvalidPage, page)
+	}
+	return (page - 1) * limit, nil
+}
+
+// filterKey splits filter[name] and filter[name][op]
+func filterKey(key string) (name, op string, err error) {
+	rest := strings.TrimPrefix(key, "filter[")
+	name, rest, ok := strings.Cut(rest, "]")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("%w: malformed key %q", ErrInvalidFilter, key)
+	}
+	if rest == "" {
+		return name, OpEq, nil
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", fmt.Errorf("%w: malformed key %q", ErrInvalidFilter, key)
+	}
+	return name, rest[1 : len(rest)-1], nil
+}
+
+func parseFilter(field Field, op, raw string) (Filter, error) {
+	switch field.Kind {
+	case String:
+		if op != OpEq {
+			return Filter{}, fmt.Errorf("%w: operator %q is not supported on %q", ErrInvalidFilter, op, field.Name)
+		}
+		return Filter{Column: field.Column, Op: op, Value: raw}, nil
+	case Time:
+		switch op {
+		case OpEq, OpGt, OpGte, OpLt, OpLte:
+		default:
+			return Filter{}, fmt.Errorf("%w: operator %q is not supported on %q", ErrInvalidFilter, op, field.Name)
+		}
+		t, err := parseTime(raw)
+		if err != nil {
+			return Filter{}, fmt.Errorf("%w: %q is not a valid date for %q", ErrInvalidFilter, raw, field.Name)
+		}
+		return Filter{Column: field.Column, Op: op, Value: t}, nil
+	}
+	return Filter{}, fmt.Errorf("%w: field %q cannot be filtered", ErrInvalidFilter, field.Name)
+}
+
+func parseTime(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
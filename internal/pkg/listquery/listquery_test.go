@@ -0,0 +1,73 @@
+package listquery
+
+import (
+	"math"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var schema = Schema{
+	Fields: []Field{
+		{Name: "email", Kind: String, Filter: true, Sort: true},
+		{Name: "date_created", Kind: Time, Filter: true, Sort: true},
+		{Name: "id", Column: "_id", Kind: String, Sort: true},
+	},
+	DefaultSort: []Sort{{Column: "date_created", Desc: true}},
+}
+
+func TestParse(t *testing.T) {
+	values, _ := url.ParseQuery("filter[email]=a@b.co&filter[date_created][gte]=2024-01-01&sort=-date_created,id&page=2")
+	q, err := Parse(values, schema)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []Filter{
+		{Column: "email", Op: OpEq, Value: "a@b.co"},
+		{Column: "date_created", Op: OpGte, Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, q.Filters)
+	assert.Equal(t, []Sort{{Column: "date_created", Desc: true}, {Column: "_id"}}, q.Sort)
+
+	q, err = Parse(url.Values{}, schema)
+	require.NoError(t, err)
+	assert.Equal(t, schema.DefaultSort, q.Sort)
+}
+
+func TestParseRejectsOutsideWhitelist(t *testing.T) {
+	for query, want := range map[string]error{
+		"filter[pass]=x":                 ErrInvalidFilter,
+		"filter[id]=x":                   ErrInvalidFilter,
+		"filter[email][gte]=a":           ErrInvalidFilter,
+		"filter[date_created][in]=2024":  ErrInvalidFilter,
+		"filter[date_created]=yesterday": ErrInvalidFilter,
+		"filter[email=x":                 ErrInvalidFilter,
+		"sort=pass":                      ErrInvalidSort,
+		"sort=email,-email":              ErrInvalidSort,
+	} {
+		values, _ := url.ParseQuery(query)
+		_, err := Parse(values, schema)
+		assert.ErrorIs(t, err, want, query)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	skip, err := Offset(1, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), skip)
+
+	skip, err = Offset(3, 20)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), skip)
+
+	for _, tt := range []struct{ page, limit int64 }{
+		{0, 20},
+		{1, 0},
+		{math.MaxInt64, 20},
+		{math.MaxInt64/20 + 2, 20},
+	} {
+		_, err := Offset(tt.page, tt.limit)
+		assert.ErrorIs(t, err, ErrInvalidPage, "page %d limit %d", tt.page, tt.limit)
+	}
+}
@@ -70,12 +70,17 @@ func FromContext(ctx context.Context) *zap.Logger {
 	if ctx == nil {
 		return Log
 	}
-	if logger, ok := ctx.Value("logger").(*zap.Logger); ok && logger != nil {
+	if logger, ok := ctx.Value(LoggerKey).(*zap.Logger); ok && logger != nil {
 		return logger
 	}
 	return Log
 }
 
+// NewContext returns a copy of ctx carrying the request-scoped logger
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, LoggerKey, logger)
+}
+
 // Sync closes the log buffer
 func Sync() error {
 	return Log.Sync()
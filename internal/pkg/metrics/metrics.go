@@ -0,0 +1,136 @@
+// Package metrics exposes Prometheus metrics for the HTTP server, outbound
+// REST calls, MongoDB and the Go runtime
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "orchestrator"
+
+// Registry holds every metric of the application
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests handled, by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests, by route template, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	httpInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served, by route template and method.",
+	}, []string{"route", "method"})
+
+	deprecatedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "deprecated_version_requests_total",
+		Help:      "Requests served by a deprecated API version, by version, route template and method.",
+	}, []string{"version", "route", "method"})
+
+	accessListBlocked = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_server",
+		Name:      "access_list_blocked_total",
+		Help:      "Requests rejected by an IP access list, by list, route template and reason.",
+	}, []string{"list", "route", "reason"})
+
+	clientRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "requests_total",
+		Help:      "Number of outbound REST requests, by integration, method and status.",
+	}, []string{"integration", "method", "status"})
+
+	clientDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of outbound REST requests, by integration, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"integration", "method", "status"})
+
+	clientInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http_client",
+		Name:      "requests_in_flight",
+		Help:      "Number of outbound REST requests currently in progress, by integration.",
+	}, []string{"integration"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, httpInFlight, deprecatedRequests, accessListBlocked,
+		clientRequests, clientDuration, clientInFlight,
+	)
+}
+
+// Handler serves the registry in the Prometheus text exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// HTTPRequestStarted increments the in-flight gauge and returns a function
+// that records the completed request
+func HTTPRequestStarted(route, method string) func(status int) {
+	start := time.Now()
+	gauge := httpInFlight.WithLabelValues(route, method)
+	gauge.Inc()
+
+	return func(status int) {
+		gauge.Dec()
+		code := strconv.Itoa(status)
+		httpRequests.WithLabelValues(route, method, code).Inc()
+		httpDuration.WithLabelValues(route, method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// DeprecatedVersionRequest counts a request served by a deprecated API version
+func DeprecatedVersionRequest(version, route, method string) {
+	deprecatedRequests.WithLabelValues(version, route, method).Inc()
+}
+
+// AccessListBlocked counts a request rejected by an IP access list
+func AccessListBlocked(list, route, reason string) {
+	accessListBlocked.WithLabelValues(list, route, reason).Inc()
+}
+
+// ClientRequestStarted increments the outbound in-flight gauge and returns a
+// function that records the completed call. A status of 0 means the request
+// failed before a response was received.
+func ClientRequestStarted(integration, method string) func(status int) {
+	start := time.Now()
+	gauge := clientInFlight.WithLabelValues(integration)
+	gauge.Inc()
+
+	return func(status int) {
+		gauge.Dec()
+		code := "error"
+		if status > 0 {
+			code = strconv.Itoa(status)
+		}
+		clientRequests.WithLabelValues(integration, method, code).Inc()
+		clientDuration.WithLabelValues(integration, method, code).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	mongoPoolConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "mongodb_pool",
+		Name:      "connections",
+		Help:      "Connections in the MongoDB pool, by state (open, in_use).",
+	}, []string{"state"})
+
+	mongoPoolEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongodb_pool",
+		Name:      "events_total",
+		Help:      "MongoDB pool events, by type (checkout_failed, cleared).",
+	}, []string{"type"})
+
+	mongoCommands = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongodb",
+		Name:      "commands_total",
+		Help:      "MongoDB commands executed, by command name and outcome.",
+	}, []string{"command", "outcome"})
+
+	mongoCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongodb",
+		Name:      "command_duration_seconds",
+		Help:      "Latency of MongoDB commands, by command name.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"command"})
+)
+
+func init() {
+	Registry.MustRegister(mongoPoolConnections, mongoPoolEvents, mongoCommands, mongoCommandDuration)
+}
+
+// MongoPoolMonitor returns a pool monitor that tracks open and checked-out connections
+func MongoPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoPoolConnections.WithLabelValues("open").Inc()
+			case event.ConnectionClosed:
+				mongoPoolConnections.WithLabelValues("open").Dec()
+			case event.GetSucceeded:
+				mongoPoolConnections.WithLabelValues("in_use").Inc()
+			case event.ConnectionReturned:
+				mongoPoolConnections.WithLabelValues("in_use").Dec()
+			case event.GetFailed:
+				mongoPoolEvents.WithLabelValues("checkout_failed").Inc()
+			case event.PoolCleared:
+				mongoPoolEvents.WithLabelValues("cleared").Inc()
+			}
+		},
+	}
+}
+
+// MongoCommandMonitor returns a command monitor that records command counts and latency
+func MongoCommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			name := strings.ToLower(e.CommandName)
+			mongoCommands.WithLabelValues(name, "success").Inc()
+			mongoCommandDuration.WithLabelValues(name).Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			name := strings.ToLower(e.CommandName)
+			mongoCommands.WithLabelValues(name, "failure").Inc()
+			mongoCommandDuration.WithLabelValues(name).Observe(e.Duration.Seconds())
+		},
+	}
+}
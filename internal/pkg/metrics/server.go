@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// NewServer creates the HTTP server that exposes the registry on its own
+// listener, separate from the public API
+func NewServer(port int, path string) *http.Server {
+	if path == "" {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, Handler())
+
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
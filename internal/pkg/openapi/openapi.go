@@ -0,0 +1,340 @@
+// Package openapi records the routes of the API together with their request
+// and response types, authentication and errors, and generates an OpenAPI 3.1
+// document from them at runtime
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Version is the OpenAPI version of the generated documents
+const Version = "3.1.0"
+
+// Security schemes
+const (
+	// AuthBearer requires an Authorization: Bearer token
+	AuthBearer = "bearerAuth"
+)
+
+var securitySchemes = map[string]any{
+	AuthBearer: map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+}
+
+// SwaggerUI is the page that renders /openapi.json with Swagger UI
+//
+//go:embed swagger.html
+var SwaggerUI []byte
+
+// Param describes a query, header or path parameter. Path parameters are
+// derived from the route template and only need a Param for a description.
+type Param struct {
+	Name        string
+	In          string // query, header or path
+	Description string
+	Required    bool
+	Type        any // zero value of the parameter type; string when nil
+}
+
+// Operation describes a route
+type Operation struct {
+	Method      string
+	Path        string // set from the mux route template
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Params      []Param
+	// Request is the zero value of the request body type, nil without body
+	Request any
+	// Response is the zero value of the type sent in the envelope data field
+	Response any
+	// Status is the success status; 200 when zero
+	Status int
+	// Auth is the security scheme required by the route, empty when public
+	Auth string
+	// Errors are the catalog codes the route can return
+	Errors []apperrors.Code
+}
+
+// Info is the metadata of the document
+type Info struct {
+	Title       string
+	Version     string
+	Description string
+}
+
+// Registry holds the documented operations
+type Registry struct {
+	mu  sync.RWMutex
+	ops []Operation
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Handle registers h on router for op.Method and path and records the
+// operation with the full path template of the route. The route is named
+// after op.OperationID.
+func (reg *Registry) Handle(router *mux.Router, path string, h http.Handler, op Operation) *mux.Route {
+	route := router.Handle(path, h).Methods(op.Method)
+	if op.OperationID != "" {
+		route.Name(op.OperationID)
+	}
+	if tpl, err := route.GetPathTemplate(); err == nil {
+		op.Path = tpl
+	} else {
+		op.Path = path
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.ops = append(reg.ops, op)
+	return route
+}
+
+// Alias documents the route registered at to with the operation recorded for
+// method at from, so routes a newer API version inherits keep their docs
+func (reg *Registry) Alias(method, from, to string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for _, op := range reg.ops {
+		if op.Method == method && op.Path == from {
+			op.Path = to
+			reg.ops = append(reg.ops, op)
+			return
+		}
+	}
+}
+
+// Update applies fn to the operation recorded for method and path
+func (reg *Registry) Update(method, path string, fn func(op *Operation)) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	for i := range reg.ops {
+		if reg.ops[i].Method == method && reg.ops[i].Path == path {
+			fn(&reg.ops[i])
+		}
+	}
+}
+
+// Remove drops the operation recorded for method and path, for routes that
+// are disabled by configuration
+func (reg *Registry) Remove(method, path string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.ops = slices.DeleteFunc(reg.ops, func(op Operation) bool {
+		return op.Method == method && op.Path == path
+	})
+}
+
+// Operations returns the recorded operations sorted by path and method
+func (reg *Registry) Operations() []Operation {
+	reg.mu.RLock()
+	ops := append([]Operation(nil), reg.ops...)
+	reg.mu.RUnlock()
+
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+var pathParam = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// Document generates the OpenAPI document of the operations under basePath.
+// Paths are made relative to basePath, which is published as the server URL.
+func (reg *Registry) Document(info Info, basePath string) map[string]any {
+	s := newSchemas()
+	s.components["Response"] = s.objectSchema(reflect.TypeOf(utils.Response{}))
+	s.components["Problem"] = s.objectSchema(reflect.TypeOf(utils.Problem{}))
+
+	paths := map[string]any{}
+	usedSchemes := map[string]any{}
+	prefix := strings.TrimSuffix(basePath, "/")
+	for _, op := range reg.Operations() {
+		rel, ok := strings.CutPrefix(op.Path, prefix)
+		if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+			continue // operación de otra versión de la API
+		}
+		if rel == "" {
+			rel = "/"
+		}
+		rel = pathParam.ReplaceAllString(rel, "{$1}")
+
+		item, _ := paths[rel].(map[string]any)
+		if item == nil {
+			item = map[string]any{}
+			paths[rel] = item
+		}
+		item[strings.ToLower(op.Method)] = s.operation(op, rel)
+
+		if op.Auth != "" {
+			if scheme, ok := securitySchemes[op.Auth]; ok {
+				usedSchemes[op.Auth] = scheme
+			}
+		}
+	}
+
+	components := map[string]any{"schemas": s.components}
+	if len(usedSchemes) > 0 {
+		components["securitySchemes"] = usedSchemes
+	}
+
+	server := basePath
+	if server == "" {
+		server = "/"
+	}
+	return map[string]any{
+		"openapi": Version,
+		"info": map[string]any{
+			"title":       info.Title,
+			"version":     info.Version,
+			"description": info.Description,
+		},
+		"servers":    []any{map[string]any{"url": server}},
+		"paths":      paths,
+		"components": components,
+	}
+}
+
+func (s *schemas) operation(op Operation, path string) map[string]any {
+	out := map[string]any{}
+	if op.OperationID != "" {
+		out["operationId"] = op.OperationID
+	}
+	if op.Summary != "" {
+		out["summary"] = op.Summary
+	}
+	if op.Description != "" {
+		out["description"] = op.Description
+	}
+	if len(op.Tags) > 0 {
+		out["tags"] = op.Tags
+	}
+	if op.Auth != "" {
+		out["security"] = []any{map[string]any{op.Auth: []string{}}}
+	}
+
+	if params := s.parameters(op, path); len(params) > 0 {
+		out["parameters"] = params
+	}
+
+	if op.Request != nil {
+		out["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.of(op.Request)},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	responses := map[string]any{
+		strconv.Itoa(status): map[string]any{
+			"description": http.StatusText(status),
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": s.envelope(op.Response)},
+			},
+		},
+	}
+	for code, resp := range errorResponses(op.Errors) {
+		responses[code] = resp
+	}
+	out["responses"] = responses
+	return out
+}
+
+// parameters merges the path parameters of the template with the declared ones
+func (s *schemas) parameters(op Operation, path string) []any {
+	declared := map[string]Param{}
+	for _, p := range op.Params {
+		declared[p.In+":"+p.Name] = p
+	}
+
+	var params []any
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		p, ok := declared["path:"+m[1]]
+		if !ok {
+			p = Param{Name: m[1], In: "path"}
+		}
+		delete(declared, "path:"+m[1])
+		p.Required = true
+		params = append(params, s.parameter(p))
+	}
+	for _, p := range op.Params {
+		if _, ok := declared[p.In+":"+p.Name]; ok {
+			params = append(params, s.parameter(p))
+		}
+	}
+	return params
+}
+
+func (s *schemas) parameter(p Param) map[string]any {
+	schema := map[string]any{"type": "string"}
+	if p.Type != nil {
+		schema = s.of(p.Type)
+	}
+	out := map[string]any{"name": p.Name, "in": p.In, "required": p.Required, "schema": schema}
+	if p.Description != "" {
+		out["description"] = p.Description
+	}
+	return out
+}
+
+// envelope is the standard response schema with data of the given type
+func (s *schemas) envelope(data any) map[string]any {
+	ref := map[string]any{"$ref": "#/components/schemas/Response"}
+	if data == nil {
+		return ref
+	}
+	return map[string]any{"allOf": []any{ref, map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"data": s.of(data)},
+	}}}
+}
+
+// errorResponses groups the catalog codes by status. Errors are returned as
+// the envelope or, when negotiated, as problem details.
+func errorResponses(codes []apperrors.Code) map[string]any {
+	byStatus := map[int][]string{}
+	for _, code := range codes {
+		def, ok := apperrors.Lookup(code)
+		if !ok {
+			continue
+		}
+		byStatus[def.Status] = append(byStatus[def.Status], "`"+string(def.Code)+"`: "+def.Message)
+	}
+
+	out := map[string]any{}
+	for status, lines := range byStatus {
+		out[strconv.Itoa(status)] = map[string]any{
+			"description": http.StatusText(status) + "\n\n" + strings.Join(lines, "\n\n"),
+			"content": map[string]any{
+				"application/json":       map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Response"}},
+				utils.ProblemContentType: map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Problem"}},
+			},
+		}
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas builds JSON schemas from Go types. Named structs are emitted once
+// under components/schemas and referenced with $ref.
+type schemas struct {
+	components map[string]any
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]any{}}
+}
+
+// of returns the schema of the type of v, or nil when v is nil
+func (s *schemas) of(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		return s.structSchema(t)
+	default:
+		// interface{} and anything else accepts any value
+		return map[string]any{}
+	}
+}
+
+func (s *schemas) structSchema(t reflect.Type) map[string]any {
+	name := schemaName(t)
+	if name == "" {
+		return s.objectSchema(t)
+	}
+	if _, exists := s.components[name]; !exists {
+		// Reserve the name first so recursive types terminate
+		s.components[name] = map[string]any{}
+		s.components[name] = s.objectSchema(t)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func (s *schemas) objectSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	s.collectFields(t, properties, &required)
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields adds the JSON fields of t, flattening embedded structs the
+// way encoding/json does
+func (s *schemas) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.collectFields(ft, properties, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := s.schema(f.Type)
+		validate := f.Tag.Get("validate")
+		if strings.Contains(validate, "email") {
+			prop = withKeyword(prop, "format", "email")
+		}
+		properties[name] = prop
+
+		if strings.Contains(validate, "required") || (!strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer) {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// withKeyword adds a keyword to a schema that is not a reference
+func withKeyword(schema map[string]any, key string, value any) map[string]any {
+	if _, isRef := schema["$ref"]; isRef {
+		return schema
+	}
+	schema[key] = value
+	return schema
+}
+
+// schemaName returns a components name for named types. Generic type
+// arguments are dropped from the package path so names stay readable.
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		return ""
+	}
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
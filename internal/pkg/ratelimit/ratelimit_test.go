@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock es un reloj manual para el limitador
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(rps float64, burst int) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(rps, burst)
+	l.now = clock.now
+	return l, clock
+}
+
+func TestAllowBurstThenRefill(t *testing.T) {
+	l, clock := newTestLimiter(2, 3)
+
+	for i := range 3 {
+		ok, _ := l.Allow("a")
+		assert.True(t, ok, "request %d within the burst", i+1)
+	}
+	ok, wait := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	// A 2 rps, medio segundo repone un token
+	clock.advance(500 * time.Millisecond)
+	ok, _ = l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+
+	// La recarga nunca supera el burst
+	clock.advance(time.Hour)
+	for range 3 {
+		ok, _ = l.Allow("a")
+		assert.True(t, ok)
+	}
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestAllowIsPerKey(t *testing.T) {
+	l, _ := newTestLimiter(1, 1)
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+
+	ok, _ = l.Allow("b")
+	assert.True(t, ok, "another key has its own bucket")
+}
+
+func TestBurstBelowOne(t *testing.T) {
+	l, _ := newTestLimiter(1, 0)
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+	ok, _ = l.Allow("a")
+	assert.False(t, ok)
+}
+
+func TestZeroRateNeverRefills(t *testing.T) {
+	l, clock := newTestLimiter(0, 1)
+
+	ok, _ := l.Allow("a")
+	assert.True(t, ok)
+	clock.advance(time.Minute)
+	ok, wait := l.Allow("a")
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+}
+
+func TestIdleBucketsAreSwept(t *testing.T) {
+	l, clock := newTestLimiter(1, 1)
+
+	l.Allow("a")
+	clock.advance(idleTTL)
+	l.Allow("b")
+
+	assert.NotContains(t, l.buckets, "a")
+	assert.Contains(t, l.buckets, "b")
+}
@@ -0,0 +1,512 @@
+// Package routetable walks the mux routers of the service and builds a table
+// of every mounted route with its methods, the middleware applied to it, its
+// authentication requirement and the module that registered it. Building the
+// table also detects duplicate and shadowed registrations.
+package routetable
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// AnyMethod is listed for routes without a method matcher
+	AnyMethod = "*"
+	// CoreModule owns the routes registered outside of a module
+	CoreModule = "core"
+)
+
+// Route is an entry of the route table
+type Route struct {
+	Listener   string   `json:"listener"`
+	Path       string   `json:"path"`
+	Name       string   `json:"name,omitempty"`
+	Prefix     bool     `json:"prefix,omitempty"`
+	Methods    []string `json:"methods"`
+	Module     string   `json:"module"`
+	Middleware []string `json:"middleware"`
+	Auth       string   `json:"auth,omitempty"`
+}
+
+// Meta is the metadata attached to a route or a router
+type Meta struct {
+	Module     string
+	Middleware []string
+	Auth       string
+}
+
+// Table records the metadata of the routers and routes of every listener
+type Table struct {
+	mu      sync.RWMutex
+	routers map[*mux.Router]Meta
+	chains  map[*mux.Router][]mux.MiddlewareFunc
+	routes  map[*mux.Route]Meta
+	tables  map[string][]Route
+}
+
+// New creates an empty table
+func New() *Table {
+	return &Table{
+		routers: make(map[*mux.Router]Meta),
+		chains:  make(map[*mux.Router][]mux.MiddlewareFunc),
+		routes:  make(map[*mux.Route]Meta),
+		tables:  make(map[string][]Route),
+	}
+}
+
+// Use adds mw to router and records it under name for every route below it
+func (t *Table) Use(router *mux.Router, name string, mw mux.MiddlewareFunc) {
+	router.Use(mw)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	meta := t.routers[router]
+	meta.Middleware = append(meta.Middleware, name)
+	t.routers[router] = meta
+	t.chains[router] = append(t.chains[router], mw)
+}
+
+// UseAuth adds an authentication middleware to router. The scheme is both
+// its middleware name and the auth requirement of the routes below it.
+func (t *Table) UseAuth(router *mux.Router, scheme string, mw mux.MiddlewareFunc) {
+	t.Use(router, scheme, mw)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	meta := t.routers[router]
+	meta.Auth = scheme
+	t.routers[router] = meta
+}
+
+// Annotate records the middleware and auth a handler applies itself, so
+// they show up in the table. It returns route for chaining.
+func (t *Table) Annotate(route *mux.Route, meta Meta) *mux.Route {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	current := t.routes[route]
+	current.Middleware = append(current.Middleware, meta.Middleware...)
+	if meta.Auth != "" {
+		current.Auth = meta.Auth
+	}
+	if meta.Module != "" {
+		current.Module = meta.Module
+	}
+	t.routes[route] = current
+	return route
+}
+
+// Own runs register and attributes every route it adds to router to module
+func (t *Table) Own(router *mux.Router, module string, register func()) {
+	before := make(map[*mux.Route]bool)
+	_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		before[route] = true
+		return nil
+	})
+
+	register()
+
+	_ = router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if !before[route] {
+			t.Annotate(route, Meta{Module: module})
+		}
+		return nil
+	})
+}
+
+// Inherited is a route copied from an older API version into a newer one
+type Inherited struct {
+	Methods []string
+	From    string // Path template in the older version
+	To      string // Path template in the newer version
+}
+
+// Inherit registers on to every route of from that to does not define
+// itself, so a newer API version falls through to the previous one. Path
+// templates are rebased from fromPrefix to toPrefix; the middleware of the
+// subrouters between from and each route is kept, while the middleware of
+// from itself is replaced by that of to. It returns the copied routes.
+func (t *Table) Inherit(from, to *mux.Router, fromPrefix, toPrefix string) []Inherited {
+	// Métodos que la versión nueva ya define, por patrón de ruta
+	defined := make(map[string][]string)
+	_ = to.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		key := patternKey(strings.TrimPrefix(tpl, toPrefix), isPrefix(route))
+		defined[key] = append(defined[key], routeMethods(route)...)
+		return nil
+	})
+
+	type source struct {
+		route   *mux.Route
+		rel     string
+		prefix  bool
+		methods []string
+		chain   []*mux.Router
+		module  string
+	}
+	var sources []source
+
+	t.mu.RLock()
+	owners := make(map[*mux.Route]*mux.Router)
+	modules := make(map[*mux.Route]string)
+	_ = from.Walk(func(route *mux.Route, r *mux.Router, ancestors []*mux.Route) error {
+		owners[route] = r
+		module := t.routes[route].Module
+		for i := len(ancestors) - 1; module == "" && i >= 0; i-- {
+			module = modules[ancestors[i]]
+		}
+		modules[route] = module
+
+		if route.GetHandler() == nil {
+			return nil
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		src := source{
+			route:  route,
+			rel:    strings.TrimPrefix(tpl, fromPrefix),
+			prefix: isPrefix(route),
+			module: module,
+		}
+
+		// Se descartan los métodos que la versión nueva redefine
+		overridden := defined[patternKey(src.rel, src.prefix)]
+		for _, m := range routeMethods(route) {
+			if !slices.Contains(overridden, m) && !slices.Contains(overridden, AnyMethod) {
+				src.methods = append(src.methods, m)
+			}
+		}
+		if len(src.methods) == 0 || (len(overridden) > 0 && slices.Contains(src.methods, AnyMethod)) {
+			return nil
+		}
+
+		// Subrouters entre from y la ruta; from queda fuera
+		for i, a := range ancestors {
+			if i > 0 {
+				src.chain = append(src.chain, owners[a])
+			}
+		}
+		if r != from {
+			src.chain = append(src.chain, r)
+		}
+		sources = append(sources, src)
+		return nil
+	})
+	t.mu.RUnlock()
+
+	inherited := make([]Inherited, 0, len(sources))
+	for _, src := range sources {
+		t.mu.RLock()
+		handler := src.route.GetHandler()
+		meta := Meta{Module: src.module}
+		for i := len(src.chain) - 1; i >= 0; i-- {
+			mws := t.chains[src.chain[i]]
+			for j := len(mws) - 1; j >= 0; j-- {
+				handler = mws[j].Middleware(handler)
+			}
+		}
+		for _, owner := range src.chain {
+			meta.Middleware = append(meta.Middleware, t.routers[owner].Middleware...)
+			if auth := t.routers[owner].Auth; auth != "" {
+				meta.Auth = auth
+			}
+		}
+		own := t.routes[src.route]
+		meta.Middleware = append(meta.Middleware, own.Middleware...)
+		if own.Auth != "" {
+			meta.Auth = own.Auth
+		}
+		t.mu.RUnlock()
+
+		var route *mux.Route
+		if src.prefix {
+			route = to.PathPrefix(src.rel).Handler(handler)
+		} else {
+			route = to.Handle(src.rel, handler)
+		}
+		if !slices.Contains(src.methods, AnyMethod) {
+			route.Methods(src.methods...)
+		}
+		if name := src.route.GetName(); name != "" {
+			route.Name(name)
+		}
+		t.Annotate(route, meta)
+
+		fromTpl, _ := src.route.GetPathTemplate()
+		toTpl, _ := route.GetPathTemplate()
+		inherited = append(inherited, Inherited{Methods: src.methods, From: fromTpl, To: toTpl})
+	}
+	return inherited
+}
+
+// Build walks router, stores its routes as the table of listener and
+// returns an error when a route is registered twice or can never match
+// because an earlier route shadows it
+func (t *Table) Build(listener string, router *mux.Router) ([]Route, error) {
+	t.mu.RLock()
+	var (
+		routes  []Route
+		leaves  []leaf
+		owners  = make(map[*mux.Route]*mux.Router)
+		modules = make(map[*mux.Route]string)
+	)
+	err := router.Walk(func(route *mux.Route, r *mux.Router, ancestors []*mux.Route) error {
+		owners[route] = r
+
+		// El módulo se hereda del subrouter que lo montó
+		module := t.routes[route].Module
+		for i := len(ancestors) - 1; module == "" && i >= 0; i-- {
+			module = modules[ancestors[i]]
+		}
+		modules[route] = module
+
+		// Los subrouters solo agrupan rutas; no atienden peticiones
+		if route.GetHandler() == nil {
+			return nil
+		}
+		tpl, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		entry := Route{
+			Listener: listener,
+			Path:     tpl,
+			Name:     route.GetName(),
+			Prefix:   isPrefix(route),
+			Methods:  routeMethods(route),
+			Module:   module,
+		}
+		if entry.Module == "" {
+			entry.Module = CoreModule
+		}
+
+		// Middleware de cada router de la cadena, de afuera hacia adentro
+		chain := make([]*mux.Router, 0, len(ancestors)+1)
+		for _, a := range ancestors {
+			chain = append(chain, owners[a])
+		}
+		chain = append(chain, r)
+		for _, owner := range chain {
+			meta := t.routers[owner]
+			entry.Middleware = append(entry.Middleware, meta.Middleware...)
+			if meta.Auth != "" {
+				entry.Auth = meta.Auth
+			}
+		}
+		meta := t.routes[route]
+		entry.Middleware = append(entry.Middleware, meta.Middleware...)
+		if meta.Auth != "" {
+			entry.Auth = meta.Auth
+		}
+		if entry.Middleware == nil {
+			entry.Middleware = []string{}
+		}
+
+		routes = append(routes, entry)
+		leaves = append(leaves, newLeaf(entry))
+		return nil
+	})
+	t.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s routes: %w", listener, err)
+	}
+
+	if err := checkConflicts(leaves); err != nil {
+		return routes, fmt.Errorf("invalid %s routes: %w", listener, err)
+	}
+
+	t.mu.Lock()
+	t.tables[listener] = routes
+	t.mu.Unlock()
+	return routes, nil
+}
+
+// Routes returns the built tables by listener
+func (t *Table) Routes() map[string][]Route {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make(map[string][]Route, len(t.tables))
+	for listener, routes := range t.tables {
+		out[listener] = slices.Clone(routes)
+	}
+	return out
+}
+
+// Listeners returns the names of the built tables, sorted
+func (t *Table) Listeners() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	names := make([]string, 0, len(t.tables))
+	for name := range t.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isPrefix reports whether route matches every path under its template
+func isPrefix(route *mux.Route) bool {
+	re, _ := route.GetPathRegexp()
+	return !strings.HasSuffix(re, "$")
+}
+
+// routeMethods returns the methods matched by route, AnyMethod when unrestricted
+func routeMethods(route *mux.Route) []string {
+	if methods, err := route.GetMethods(); err == nil {
+		return methods
+	}
+	return []string{AnyMethod}
+}
+
+// patternKey identifies the requests a path template matches, ignoring the
+// names of its variables
+func patternKey(path string, prefix bool) string {
+	var b strings.Builder
+	for _, seg := range newLeaf(Route{Path: path}).segments {
+		b.WriteByte('/')
+		if seg.variable {
+			b.WriteString("{:" + seg.pattern + "}")
+		} else {
+			b.WriteString(seg.literal)
+		}
+	}
+	if prefix {
+		b.WriteString("/*")
+	}
+	return b.String()
+}
+
+// SamePattern reports whether two path templates match the same requests,
+// ignoring the names of their variables
+func SamePattern(a, b string) bool {
+	return patternKey(a, false) == patternKey(b, false)
+}
+
+// leaf is a route prepared for conflict detection
+type leaf struct {
+	route    Route
+	segments []segment
+}
+
+// segment is a path segment: a literal, or a variable with its pattern
+type segment struct {
+	literal  string
+	variable bool
+	pattern  string // empty for the default pattern, [^/]+
+}
+
+var pathVar = regexp.MustCompile(`^\{([^}:]+)(?::(.*))?\}$`)
+
+func newLeaf(r Route) leaf {
+	l := leaf{route: r}
+	for _, part := range strings.Split(strings.Trim(r.Path, "/"), "/") {
+		if part == "" {
+			continue
+		}
+		if m := pathVar.FindStringSubmatch(part); m != nil {
+			l.segments = append(l.segments, segment{variable: true, pattern: m[2]})
+			continue
+		}
+		l.segments = append(l.segments, segment{literal: part})
+	}
+	return l
+}
+
+// checkConflicts reports the first route that is registered twice or that
+// can never match because a route registered before it matches every
+// request it would
+func checkConflicts(leaves []leaf) error {
+	for i, later := range leaves {
+		for _, earlier := range leaves[:i] {
+			if duplicate(earlier, later) {
+				return fmt.Errorf("duplicate route %s %s registered by %s and %s",
+					strings.Join(later.route.Methods, ","), later.route.Path, earlier.route.Module, later.route.Module)
+			}
+			if coversMethods(earlier.route.Methods, later.route.Methods) && shadows(earlier, later) {
+				return fmt.Errorf("route %s %s (%s) is shadowed by %s %s (%s)",
+					strings.Join(later.route.Methods, ","), later.route.Path, later.route.Module,
+					strings.Join(earlier.route.Methods, ","), earlier.route.Path, earlier.route.Module)
+			}
+		}
+	}
+	return nil
+}
+
+// duplicate reports whether both routes have the same path pattern and
+// share at least one method
+func duplicate(a, b leaf) bool {
+	if a.route.Prefix != b.route.Prefix || len(a.segments) != len(b.segments) {
+		return false
+	}
+	for i := range a.segments {
+		if a.segments[i] != b.segments[i] {
+			return false
+		}
+	}
+	for _, m := range b.route.Methods {
+		if m == AnyMethod || slices.Contains(a.route.Methods, m) || slices.Contains(a.route.Methods, AnyMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// coversMethods reports whether every method of later is matched by earlier
+func coversMethods(earlier, later []string) bool {
+	if slices.Contains(earlier, AnyMethod) {
+		return true
+	}
+	if slices.Contains(later, AnyMethod) {
+		return false
+	}
+	for _, m := range later {
+		if !slices.Contains(earlier, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// shadows reports whether the path of earlier matches every path later does
+func shadows(earlier, later leaf) bool {
+	n := len(earlier.segments)
+	if earlier.route.Prefix {
+		if n > len(later.segments) {
+			return false
+		}
+	} else if later.route.Prefix || n != len(later.segments) {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		if !segmentCovers(earlier.segments[i], later.segments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func segmentCovers(earlier, later segment) bool {
+	switch {
+	case !earlier.variable:
+		return !later.variable && earlier.literal == later.literal
+	case earlier.pattern == "":
+		return true
+	case later.variable:
+		return earlier.pattern == later.pattern
+	default:
+		re, err := regexp.Compile("^(?:" + earlier.pattern + ")$")
+		return err == nil && re.MatchString(later.literal)
+	}
+}
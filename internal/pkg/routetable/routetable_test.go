@@ -0,0 +1,128 @@
+package routetable
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noop(w http.ResponseWriter, r *http.Request) {}
+
+func passthrough(next http.Handler) http.Handler { return next }
+
+func TestBuildListsModuleMiddlewareAndAuth(t *testing.T) {
+	table := New()
+	r := mux.NewRouter()
+	table.Use(r, "request-id", passthrough)
+	api := r.PathPrefix("/api").Subrouter()
+
+	table.Own(api, "users", func() {
+		users := api.PathPrefix("/users").Subrouter()
+		table.UseAuth(users, "jwt", passthrough)
+		users.HandleFunc("", noop).Methods(http.MethodGet)
+		table.Annotate(users.HandleFunc("/{id}", noop).Methods(http.MethodPut), Meta{Middleware: []string{"etag"}})
+	})
+	api.HandleFunc("/health", noop)
+
+	routes, err := table.Build("http", r)
+	require.NoError(t, err)
+	assert.Equal(t, []Route{
+		{Listener: "http", Path: "/api/users", Methods: []string{"GET"}, Module: "users", Middleware: []string{"request-id", "jwt"}, Auth: "jwt"},
+		{Listener: "http", Path: "/api/users/{id}", Methods: []string{"PUT"}, Module: "users", Middleware: []string{"request-id", "jwt", "etag"}, Auth: "jwt"},
+		{Listener: "http", Path: "/api/health", Methods: []string{AnyMethod}, Module: CoreModule, Middleware: []string{"request-id"}},
+	}, routes)
+	assert.Equal(t, []string{"http"}, table.Listeners())
+}
+
+func TestBuildRejectsDuplicateRoute(t *testing.T) {
+	table := New()
+	r := mux.NewRouter()
+	table.Own(r, "users", func() { r.HandleFunc("/users", noop).Methods(http.MethodGet, http.MethodPost) })
+	table.Own(r, "legacy", func() { r.HandleFunc("/users", noop).Methods(http.MethodPost) })
+
+	_, err := table.Build("http", r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate route POST /users registered by users and legacy")
+}
+
+func TestBuildRejectsShadowedRoute(t *testing.T) {
+	table := New()
+	r := mux.NewRouter()
+	r.HandleFunc("/users/{id}", noop).Methods(http.MethodGet)
+	r.HandleFunc("/users/me", noop).Methods(http.MethodGet)
+
+	_, err := table.Build("http", r)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "route GET /users/me (core) is shadowed by GET /users/{id} (core)")
+}
+
+func TestBuildAllowsNonOverlappingRoutes(t *testing.T) {
+	table := New()
+	r := mux.NewRouter()
+	r.HandleFunc("/users/me", noop).Methods(http.MethodGet)
+	r.HandleFunc("/users/{id:[0-9]+}", noop).Methods(http.MethodGet)
+	r.HandleFunc("/users/{name}", noop).Methods(http.MethodGet)
+	r.HandleFunc("/users/{id}", noop).Methods(http.MethodDelete)
+	r.PathPrefix("/debug/").HandlerFunc(noop)
+
+	_, err := table.Build("http", r)
+	assert.NoError(t, err)
+}
+
+func TestInheritFallsThroughToPreviousVersion(t *testing.T) {
+	table := New()
+	r := mux.NewRouter()
+	v1 := r.PathPrefix("/api/v1").Subrouter()
+	v2 := r.PathPrefix("/api/v2").Subrouter()
+
+	calls := map[string]string{}
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { calls[r.Method+" "+r.URL.Path] = name }
+	}
+	tagged := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Users", "true")
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	table.Own(v1, "users", func() {
+		users := v1.PathPrefix("/users").Subrouter()
+		table.Use(users, "tagged", tagged)
+		users.HandleFunc("", handler("v1-list")).Methods(http.MethodGet, http.MethodPost)
+		users.HandleFunc("/{id}", handler("v1-get")).Methods(http.MethodGet)
+	})
+	v2.HandleFunc("/users", handler("v2-list")).Methods(http.MethodGet)
+
+	inherited := table.Inherit(v1, v2, "/api/v1", "/api/v2")
+	assert.Equal(t, []Inherited{
+		{Methods: []string{"POST"}, From: "/api/v1/users", To: "/api/v2/users"},
+		{Methods: []string{"GET"}, From: "/api/v1/users/{id}", To: "/api/v2/users/{id}"},
+	}, inherited)
+
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v2/users"},
+		{http.MethodPost, "/api/v2/users"},
+		{http.MethodGet, "/api/v2/users/42"},
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(req.method, req.path, nil))
+		assert.Equal(t, http.StatusOK, rec.Code, req.path)
+	}
+	assert.Equal(t, "v2-list", calls["GET /api/v2/users"])
+	assert.Equal(t, "v1-list", calls["POST /api/v2/users"])
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v2/users/42", nil))
+	assert.Equal(t, "v1-get", calls["GET /api/v2/users/42"])
+	assert.Equal(t, "true", rec.Header().Get("X-Users"), "subrouter middleware must be kept")
+
+	routes, err := table.Build("http", r)
+	require.NoError(t, err)
+	assert.Equal(t, "users", routes[len(routes)-1].Module)
+	assert.Equal(t, []string{"tagged"}, routes[len(routes)-1].Middleware)
+}
@@ -0,0 +1,269 @@
+// Package tlsconfig builds the server TLS configuration, including mutual TLS,
+// from files or from the certificates of the JSON configuration, and reloads
+// certificates from disk without a restart
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+const defaultReloadInterval = time.Minute
+
+var clientAuthModes = map[string]tls.ClientAuthType{
+	"":                   tls.NoClientCert,
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify_if_given":    tls.VerifyClientCertIfGiven,
+	"require_and_verify": tls.RequireAndVerifyClientCert,
+}
+
+var minVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Reloader keeps the server certificate and client CA pool up to date with
+// the files on disk
+type Reloader struct {
+	cfg      config.TLSConfig
+	interval time.Duration
+
+	cert     atomic.Pointer[tls.Certificate]
+	clientCA atomic.Pointer[x509.CertPool]
+
+	mu      sync.Mutex
+	modTime map[string]time.Time
+}
+
+// New validates the TLS configuration and loads the initial certificate and
+// client CA bundle
+func New(cfg config.TLSConfig) (*Reloader, error) {
+	if _, ok := clientAuthModes[strings.ToLower(cfg.ClientAuth)]; !ok {
+		return nil, fmt.Errorf("unknown client_auth mode %q", cfg.ClientAuth)
+	}
+	if _, ok := minVersions[cfg.MinVersion]; !ok {
+		return nil, fmt.Errorf("unsupported min_version %q", cfg.MinVersion)
+	}
+
+	interval, err := time.ParseDuration(cfg.ReloadInterval)
+	if err != nil || interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	r := &Reloader{cfg: cfg, interval: interval, modTime: make(map[string]time.Time)}
+	if err := r.reload(true); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Config returns the tls.Config for the HTTP server. Certificates and the
+// client CA pool are resolved per handshake, so reloads apply to new
+// connections immediately.
+func (r *Reloader) Config() (*tls.Config, error) {
+	suites, err := cipherSuites(r.cfg.CipherSuites)
+	if err != nil {
+		return nil, err
+	}
+
+	base := &tls.Config{
+		MinVersion:   minVersions[r.cfg.MinVersion],
+		CipherSuites: suites,
+		ClientAuth:   clientAuthModes[strings.ToLower(r.cfg.ClientAuth)],
+		NextProtos:   []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.cert.Load(), nil
+		},
+	}
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		c := base.Clone()
+		c.GetConfigForClient = nil
+		c.ClientCAs = r.clientCA.Load()
+		return c, nil
+	}
+	return base, nil
+}
+
+// Start polls the certificate files every reload interval until ctx is done
+func (r *Reloader) Start(ctx context.Context) {
+	if r.cfg.CertFile == "" && r.cfg.ClientCAFile == "" {
+		return
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reload(false); err != nil {
+				logger.Log.Error("Failed to reload TLS certificates, keeping the current ones", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reload loads the certificate and CA bundle when their files changed
+func (r *Reloader) reload(force bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if force || r.changed(r.cfg.CertFile) || r.changed(r.cfg.KeyFile) {
+		certPEM, keyPEM, err := r.keyPair()
+		if err != nil {
+			return err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return fmt.Errorf("invalid server certificate: %w", err)
+		}
+		r.cert.Store(&cert)
+		r.markLoaded(r.cfg.CertFile, r.cfg.KeyFile)
+		if !force {
+			logger.Log.Info("TLS certificate reloaded", zap.String("cert_file", r.cfg.CertFile))
+		}
+	}
+
+	if force || r.changed(r.cfg.ClientCAFile) {
+		caPEM, err := r.clientCABundle()
+		if err != nil {
+			return err
+		}
+		if caPEM != nil {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return errors.New("client CA bundle contains no valid certificates")
+			}
+			r.clientCA.Store(pool)
+			r.markLoaded(r.cfg.ClientCAFile)
+		}
+	}
+
+	mode := clientAuthModes[strings.ToLower(r.cfg.ClientAuth)]
+	if (mode == tls.VerifyClientCertIfGiven || mode == tls.RequireAndVerifyClientCert) && r.clientCA.Load() == nil {
+		return errors.New("client certificate verification requires client_ca_file or client_ca_name")
+	}
+	return nil
+}
+
+// keyPair reads the certificate and key from files, or from the named
+// entries of JSONConfig.Certificates when no files are configured
+func (r *Reloader) keyPair() ([]byte, []byte, error) {
+	if r.cfg.CertFile != "" || r.cfg.KeyFile != "" {
+		certPEM, err := os.ReadFile(r.cfg.CertFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading certificate file: %w", err)
+		}
+		keyPEM, err := os.ReadFile(r.cfg.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading key file: %w", err)
+		}
+		return certPEM, keyPEM, nil
+	}
+
+	certPEM, err := namedCertificate(r.cfg.CertName)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := namedCertificate(r.cfg.KeyName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certPEM, keyPEM, nil
+}
+
+// clientCABundle returns the CA bundle used to verify client certificates,
+// or nil when none is configured
+func (r *Reloader) clientCABundle() ([]byte, error) {
+	if r.cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client CA file: %w", err)
+		}
+		return data, nil
+	}
+	if r.cfg.ClientCAName != "" {
+		return namedCertificate(r.cfg.ClientCAName)
+	}
+	return nil, nil
+}
+
+func (r *Reloader) changed(path string) bool {
+	if path == "" {
+		return false
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.ModTime().Equal(r.modTime[path])
+}
+
+func (r *Reloader) markLoaded(paths ...string) {
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if info, err := os.Stat(p); err == nil {
+			r.modTime[p] = info.ModTime()
+		}
+	}
+}
+
+// namedCertificate reads a PEM value from JSONConfig.Certificates. Values
+// may be stored as PEM text or as base64-encoded PEM.
+func namedCertificate(name string) ([]byte, error) {
+	if name == "" {
+		return nil, errors.New("TLS requires cert_file/key_file or cert_name/key_name")
+	}
+	value, ok := utils.GetCertificate(name)
+	if !ok {
+		return nil, fmt.Errorf("certificate %q not found in JSON config", name)
+	}
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(strings.ReplaceAll(value, `\n`, "\n")), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("certificate %q is neither PEM nor base64: %w", name, err)
+	}
+	return decoded, nil
+}
+
+func cipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	available := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		available[s.Name] = s.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, n := range names {
+		id, ok := available[n]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite %q", n)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
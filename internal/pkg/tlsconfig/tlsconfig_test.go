@@ -0,0 +1,202 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA firma certificados de servidor y de cliente para las pruebas
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue devuelve el certificado y la clave PEM de serial firmados por la CA
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func servedSerial(t *testing.T, r *Reloader) int64 {
+	t.Helper()
+	cfg, err := r.Config()
+	require.NoError(t, err)
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.SerialNumber.Int64()
+}
+
+func TestReloadPicksUpNewCertificate(t *testing.T) {
+	_ = logger.InitLogger(false)
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+
+	past := time.Now().Add(-time.Minute)
+	certPEM, keyPEM := ca.issue(t, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, past)
+	writeFile(t, keyFile, keyPEM, past)
+
+	r, err := New(config.TLSConfig{CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), servedSerial(t, r))
+
+	// Sin cambios en disco no se recarga
+	require.NoError(t, r.reload(false))
+	assert.Equal(t, int64(10), servedSerial(t, r))
+
+	certPEM, keyPEM = ca.issue(t, 11, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+	require.NoError(t, r.reload(false))
+	assert.Equal(t, int64(11), servedSerial(t, r))
+
+	// Un certificado inválido conserva el anterior
+	writeFile(t, certFile, []byte("not a certificate"), time.Now().Add(time.Minute))
+	assert.Error(t, r.reload(false))
+	assert.Equal(t, int64(11), servedSerial(t, r))
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	certPEM, keyPEM := ca.issue(t, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+
+	tests := []struct {
+		name string
+		cfg  config.TLSConfig
+	}{
+		{"UnknownClientAuth", config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientAuth: "always"}},
+		{"UnsupportedMinVersion", config.TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: "1.0"}},
+		{"VerifyWithoutClientCA", config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientAuth: "require_and_verify"}},
+		{"MissingKeyFile", config.TLSConfig{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.key")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMutualTLSRequiresTrustedClientCertificate(t *testing.T) {
+	_ = logger.InitLogger(false)
+	serverCA, clientCA, otherCA := newTestCA(t), newTestCA(t), newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "client-ca.pem")
+	certPEM, keyPEM := serverCA.issue(t, 10, x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+	writeFile(t, caFile, clientCA.pem, time.Now())
+
+	r, err := New(config.TLSConfig{
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		ClientAuth:   "require_and_verify",
+		ClientCAFile: caFile,
+	})
+	require.NoError(t, err)
+	tlsCfg, err := r.Config()
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})}
+	go func() { _ = srv.Serve(tls.NewListener(ln, tlsCfg)) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	url := "https://" + ln.Addr().String()
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(serverCA.pem)
+	client := func(ca *testCA) *http.Client {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+		if ca != nil {
+			certPEM, keyPEM := ca.issue(t, 20, x509.ExtKeyUsageClientAuth)
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			require.NoError(t, err)
+			transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		}
+		return &http.Client{Transport: transport, Timeout: 5 * time.Second}
+	}
+
+	resp, err := client(clientCA).Get(url)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, err = client(nil).Get(url)
+	assert.Error(t, err, "a client without certificate must be rejected")
+
+	_, err = client(otherCA).Get(url)
+	assert.Error(t, err, "a certificate from another CA must be rejected")
+}
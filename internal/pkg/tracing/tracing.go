@@ -0,0 +1,79 @@
+// Package tracing configures OpenTelemetry distributed tracing with W3C
+// trace context propagation and OTLP/HTTP export
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "api-ptf-core-business-orchestrator-go-ms"
+
+func init() {
+	// Propagate traceparent/tracestate even when export is disabled, so
+	// incoming trace IDs still reach logs and outbound calls
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Init installs the global tracer provider exporting spans through OTLP/HTTP.
+// The endpoint is taken from collector_endpoint, or from the standard
+// OTEL_EXPORTER_OTLP_* environment variables when empty. The returned
+// function flushes pending spans and must be called on shutdown.
+func Init(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	tracingCfg := cfg.Observability.Tracing
+
+	var opts []otlptracehttp.Option
+	if tracingCfg.CollectorEndpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(tracingCfg.CollectorEndpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	serviceName := tracingCfg.ServiceName
+	if serviceName == "" {
+		serviceName = cfg.AppName
+	}
+	res := resource.NewWithAttributes("",
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", cfg.Version),
+		attribute.String("deployment.environment", cfg.Environment),
+	)
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(tracingCfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the application tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// TraceID returns the trace ID of the span in ctx, or "" when there is none
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
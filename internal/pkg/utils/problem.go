@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/apperrors"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/i18n"
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+	Datetime string `json:"datetime"`
+}
+
+// BodyError maps an error reading or decoding the request body: bodies over
+// the route limit are PAYLOAD_TOO_LARGE, anything else INVALID_REQUEST_BODY
+func BodyError(err error) *apperrors.Error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return apperrors.New(apperrors.CodePayloadTooLarge, err)
+	}
+	return apperrors.New(apperrors.CodeInvalidRequestBody, err)
+}
+
+// WriteError writes err as the standard envelope or, when the client accepts
+// application/problem+json, as problem details. Only the public message of
+// the catalog reaches the client; the internal cause is logged.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	appErr := apperrors.From(err)
+
+	log := logger.FromContext(r.Context()).With(
+		zap.String("error_code", string(appErr.Code)),
+		zap.Int("status", appErr.Status),
+	)
+	if appErr.IsServerError() {
+		log.Error("Request failed", zap.Error(appErr.Cause))
+	} else if appErr.Cause != nil {
+		log.Warn("Request rejected", zap.Error(appErr.Cause))
+	}
+
+	message, ok := i18n.Translate(w.Header().Get("Content-Language"), appErr.Key)
+	if !ok {
+		message = appErr.Message
+	}
+
+	if !acceptsProblem(r) {
+		return SendError(w, appErr.Status, string(appErr.Code), message)
+	}
+
+	problem := Problem{
+		Type:     "urn:problem-type:" + strings.ToLower(strings.ReplaceAll(string(appErr.Code), "_", "-")),
+		Title:    appErr.Title,
+		Status:   appErr.Status,
+		Detail:   message,
+		Instance: r.URL.Path,
+		Code:     string(appErr.Code),
+		Datetime: timeNow(),
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(appErr.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		return ErrEncodingFailed
+	}
+	return nil
+}
+
+// acceptsProblem reports whether the Accept header lists problem+json
+func acceptsProblem(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || mediaType != ProblemContentType {
+			continue
+		}
+		return params["q"] != "0"
+	}
+	return false
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/pkg/i18n"
 )
 
 // Códigos de error comunes para respuestas HTTP
@@ -18,14 +20,27 @@ const (
 	CodeForbidden = "403"
 	// CodeNotFound (404) indica que el recurso solicitado no existe
 	CodeNotFound = "404"
+	// CodeConflict (409) indica que la solicitud entra en conflicto con el estado actual del recurso
+	CodeConflict = "409"
+	// CodePreconditionFailed (412) indica que una precondición (If-Match) no se cumplió
+	CodePreconditionFailed = "412"
+	// CodeUnprocessableEntity (422) indica que la solicitud es válida pero no se puede procesar
+	CodeUnprocessableEntity = "422"
 	// CodeInternalServerError (500) indica un error interno del servidor
 	CodeInternalServerError = "500"
+	// CodeServiceUnavailable (503) indica que el servicio no está disponible temporalmente
+	CodeServiceUnavailable = "503"
 )
 
 const (
 	timeFormat = "2006-01-02T15:04:05.000Z"
 )
 
+// timeNow returns the current time in the response datetime format
+func timeNow() string {
+	return time.Now().UTC().Format(timeFormat)
+}
+
 var (
 	ErrNilWriter      = errors.New("response writer cannot be nil")
 	ErrEmptyMessage   = errors.New("message cannot be empty")
@@ -54,7 +69,7 @@ func NewResponse(code, message string, data interface{}) (*Response, error) {
 	return &Response{
 		Code:     code,
 		Message:  message,
-		Datetime: time.Now().UTC().Format(timeFormat),
+		Datetime: timeNow(),
 		Data:     data,
 	}, nil
 }
@@ -77,8 +92,15 @@ func (r *Response) WriteJSON(w http.ResponseWriter, statusCode int) error {
 	return nil
 }
 
-// sendResponse is a helper function to send a response with the given parameters
+// sendResponse is a helper function to send a response with the given parameters.
+// The message is localized to the Content-Language negotiated for the request
+// when it is a key of the i18n bundles; any other text is sent as is.
 func sendResponse(w http.ResponseWriter, code, message string, data interface{}, statusCode int) error {
+	if w == nil {
+		return ErrNilWriter
+	}
+	message = i18n.Message(w.Header().Get("Content-Language"), message)
+
 	resp, err := NewResponse(code, message, data)
 	if err != nil {
 		return err
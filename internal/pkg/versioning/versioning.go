@@ -0,0 +1,129 @@
+// Package versioning describes the API versions mounted side by side under
+// the API root and negotiates the version of unversioned requests from the
+// Accept-Version header
+package versioning
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+)
+
+const (
+	// AcceptVersionHeader selects the version of an unversioned path
+	AcceptVersionHeader = "Accept-Version"
+	// VersionHeader tells the client which version served the response
+	VersionHeader = "API-Version"
+)
+
+// Version is an API version and its lifecycle
+type Version struct {
+	Name         string
+	Path         string // Full path prefix, e.g. /api/v2
+	Deprecated   bool
+	DeprecatedAt time.Time // Zero when the deprecation has no date
+	Sunset       time.Time // Zero when no sunset is planned
+	Link         string
+}
+
+// Set is the ordered list of versions, oldest first
+type Set struct {
+	root     string
+	def      string
+	versions []Version
+}
+
+// New builds the versions declared in the HTTP configuration. The
+// configuration is validated when it is loaded.
+func New(cfg config.HTTPConfig) *Set {
+	s := &Set{root: cfg.APIRoot(), def: cfg.Versioning.Default}
+	for _, vc := range cfg.Versioning.Versions {
+		v := Version{
+			Name:       vc.Name,
+			Path:       cfg.VersionPath(vc.Name),
+			Deprecated: vc.Deprecated,
+			Link:       vc.Link,
+		}
+		v.DeprecatedAt, _ = time.Parse(time.DateOnly, vc.DeprecatedAt)
+		v.Sunset, _ = time.Parse(time.DateOnly, vc.Sunset)
+		s.versions = append(s.versions, v)
+	}
+	return s
+}
+
+// Root returns the path the versions are mounted under
+func (s *Set) Root() string { return s.root }
+
+// All returns the versions, oldest first
+func (s *Set) All() []Version {
+	return append([]Version(nil), s.versions...)
+}
+
+// Lookup returns the version with the given name
+func (s *Set) Lookup(name string) (Version, bool) {
+	for _, v := range s.versions {
+		if strings.EqualFold(v.Name, name) {
+			return v, true
+		}
+	}
+	return Version{}, false
+}
+
+// Default returns the version of unversioned requests without Accept-Version
+func (s *Set) Default() Version {
+	v, _ := s.Lookup(s.def)
+	return v
+}
+
+// Negotiate returns the version requested by an Accept-Version value. An
+// empty value selects the default version; "2" is accepted for "v2".
+func (s *Set) Negotiate(accept string) (Version, error) {
+	accept = strings.TrimSpace(accept)
+	if accept == "" {
+		return s.Default(), nil
+	}
+	if v, ok := s.Lookup(accept); ok {
+		return v, nil
+	}
+	if v, ok := s.Lookup("v" + accept); ok {
+		return v, nil
+	}
+	return Version{}, fmt.Errorf("unsupported API version %q", accept)
+}
+
+// WriteHeaders sets the API-Version header and, for deprecated versions,
+// the Deprecation (RFC 9745), Sunset (RFC 8594) and deprecation Link headers
+func (v Version) WriteHeaders(h http.Header) {
+	h.Set(VersionHeader, v.Name)
+	if !v.Deprecated {
+		return
+	}
+	if v.DeprecatedAt.IsZero() {
+		h.Set("Deprecation", "true")
+	} else {
+		h.Set("Deprecation", fmt.Sprintf("@%d", v.DeprecatedAt.Unix()))
+	}
+	if !v.Sunset.IsZero() {
+		h.Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if v.Link != "" {
+		h.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"`, v.Link))
+	}
+}
+
+type contextKey struct{}
+
+// WithVersion stores the version serving the request in ctx
+func WithVersion(ctx context.Context, v Version) context.Context {
+	return context.WithValue(ctx, contextKey{}, v)
+}
+
+// FromContext returns the version serving the request
+func FromContext(ctx context.Context) (Version, bool) {
+	v, ok := ctx.Value(contextKey{}).(Version)
+	return v, ok
+}
@@ -0,0 +1,84 @@
+package versioning
+
+import (
+	"net/http"
+	"testing"
+
+	"api-ptf-core-business-orchestrator-go-ms/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSet() *Set {
+	return New(config.HTTPConfig{
+		BasePath: "/api/v1",
+		Versioning: config.VersioningConfig{
+			Default: "v1",
+			Versions: []config.VersionConfig{
+				{Name: "v1", Deprecated: true, DeprecatedAt: "2026-01-01", Sunset: "2027-01-01", Link: "https://example.com/migrate"},
+				{Name: "v2"},
+			},
+		},
+	})
+}
+
+func TestNew(t *testing.T) {
+	s := testSet()
+
+	assert.Equal(t, "/api", s.Root())
+	all := s.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "/api/v1", all[0].Path)
+	assert.Equal(t, "/api/v2", all[1].Path)
+	assert.Equal(t, "v1", s.Default().Name)
+}
+
+func TestNegotiate(t *testing.T) {
+	s := testSet()
+
+	tests := []struct {
+		accept  string
+		want    string
+		wantErr bool
+	}{
+		{"", "v1", false},
+		{"  ", "v1", false},
+		{"v2", "v2", false},
+		{"V2", "v2", false},
+		{"2", "v2", false},
+		{" 1 ", "v1", false},
+		{"v3", "", true},
+		{"latest", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			v, err := s.Negotiate(tt.accept)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v.Name)
+		})
+	}
+}
+
+func TestWriteHeaders(t *testing.T) {
+	s := testSet()
+
+	h := http.Header{}
+	v2, _ := s.Lookup("v2")
+	v2.WriteHeaders(h)
+	assert.Equal(t, "v2", h.Get(VersionHeader))
+	assert.Empty(t, h.Get("Deprecation"))
+	assert.Empty(t, h.Get("Sunset"))
+
+	h = http.Header{}
+	v1, _ := s.Lookup("v1")
+	v1.WriteHeaders(h)
+	assert.Equal(t, "v1", h.Get(VersionHeader))
+	assert.Equal(t, "@1767225600", h.Get("Deprecation"))
+	assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", h.Get("Sunset"))
+	assert.Equal(t, `<https://example.com/migrate>; rel="deprecation"`, h.Get("Link"))
+}